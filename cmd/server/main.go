@@ -0,0 +1,142 @@
+// Command server runs the Docker Compose MCP server, serving the MCP
+// protocol over stdio.
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/jontolof/docker-compose-mcp/internal/compose"
+	"github.com/jontolof/docker-compose-mcp/internal/config"
+	"github.com/jontolof/docker-compose-mcp/internal/dockerhost"
+	"github.com/jontolof/docker-compose-mcp/internal/logging"
+	"github.com/jontolof/docker-compose-mcp/internal/mcp"
+	"github.com/jontolof/docker-compose-mcp/internal/plugin"
+	"github.com/jontolof/docker-compose-mcp/internal/server"
+	"github.com/jontolof/docker-compose-mcp/internal/shutdown"
+	"github.com/jontolof/docker-compose-mcp/internal/workspace"
+)
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	workDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("get working directory: %w", err)
+	}
+
+	cfg := config.Load()
+
+	logOut := io.Writer(os.Stderr)
+	if cfg.LogFile != "" {
+		fileLogger, err := logging.NewFileLogger(cfg.LogFile, cfg.LogMaxSize, cfg.LogMaxBackups)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "logging: %v\n", err)
+		} else {
+			defer fileLogger.Close()
+			logOut = fileLogger
+		}
+	}
+
+	statePath := filepath.Join(workDir, ".mcp", "workspace.json")
+	ws := workspace.NewManager(statePath, workDir)
+
+	hostsStatePath := filepath.Join(workDir, ".mcp", "hosts.json")
+	hosts := dockerhost.NewHostManager(hostsStatePath)
+	repo := compose.NewRepository(ws.Dir, hosts.Active, ws.ComposeFile, ws.Variables)
+	configCachePath := filepath.Join(workDir, ".mcp", "config_cache.json")
+	composeService := compose.NewService(repo, configCachePath)
+	composeService.EnableQueue(cfg.QueueModeEnabled)
+	composeService.Metrics().SetCostModel(cfg.MetricsCharsPerToken, cfg.MetricsCostPerToken)
+	composeService.SetConfig(cfg)
+	for _, err := range composeService.Filter().SetRedactPatterns(cfg.RedactPatterns) {
+		fmt.Fprintf(logOut, "redact patterns: %v\n", err)
+	}
+	composeService.Filter().SetMaxOutputBytes(cfg.FilterMaxBytes)
+	composeController := compose.NewController(composeService, ws, cfg)
+
+	var plugins *plugin.Manager
+
+	providers := []toolProvider{
+		composeController,
+		server.NewFeaturesController(cfg, composeService.ConfigCache()),
+		server.NewFilterController(composeService.Filter()),
+		server.NewSelfTestController(nil),
+		server.NewWorkspaceController(ws, cfg.ComposeValidationStrict),
+		server.NewDockerContextController(hosts),
+	}
+
+	if cfg.PluginsEnabled {
+		pluginDir := filepath.Join(workDir, ".mcp", "plugins")
+		plugins = plugin.NewManager([]string{pluginDir})
+		plugins.EnableGitInstall(cfg.PluginGitInstallEnabled)
+		plugins.SetHookTimeout(time.Duration(cfg.PluginHookTimeout) * time.Second)
+		plugins.SetEnvironment(cfg.Environment)
+		if err := plugins.Initialize(); err != nil {
+			fmt.Fprintf(logOut, "plugins: %v\n", err)
+		}
+		defer plugins.Shutdown()
+
+		if cfg.PluginHotReloadEnabled {
+			hotReloadCtx, cancelHotReload := context.WithCancel(context.Background())
+			plugins.StartHotReload(hotReloadCtx, 0)
+			defer cancelHotReload()
+		}
+
+		composeService.SetEventManager(plugins)
+		hosts.SetEventManager(plugins)
+		ws.SetEventManager(plugins)
+		providers = append(providers, server.NewPluginController(plugins))
+	}
+
+	providers = append(providers, server.NewHealthController(plugins, composeService.ConfigCache(), composeController.Sessions(), hosts, composeService.Metrics()))
+
+	mcpServer := mcp.NewServer()
+	for _, provider := range providers {
+		for _, tool := range provider.Tools() {
+			mcpServer.Register(tool)
+		}
+	}
+
+	shutdownMgr := shutdown.NewManager()
+	shutdownMgr.Register("compose commands", func(ctx context.Context) error {
+		result := composeService.Drain(ctx)
+		fmt.Fprintf(logOut, "shutdown: drained %d compose command(s), cancelled %d\n", result.Drained, result.Cancelled)
+		return nil
+	})
+	shutdownMgr.Register("sessions", func(ctx context.Context) error {
+		composeController.Close()
+		return nil
+	})
+
+	shutdownTimeout := time.Duration(cfg.ShutdownTimeout) * time.Second
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-sigCh
+		shutdownMgr.Run(shutdownTimeout, logOut)
+		os.Exit(0)
+	}()
+
+	serveErr := mcpServer.ServeStdio(context.Background(), os.Stdin, os.Stdout)
+	shutdownMgr.Run(shutdownTimeout, logOut)
+	return serveErr
+}
+
+// toolProvider is implemented by every controller that contributes MCP
+// tools to the server.
+type toolProvider interface {
+	Tools() []mcp.Tool
+}
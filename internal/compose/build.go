@@ -0,0 +1,206 @@
+package compose
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jontolof/docker-compose-mcp/internal/executor"
+)
+
+// BuildParams are the arguments to the compose_build tool.
+type BuildParams struct {
+	Services   []string `json:"services,omitempty"`
+	Parallel   bool     `json:"parallel,omitempty"`
+	MaxWorkers int      `json:"maxWorkers,omitempty"`
+	// Profiles, when Services is empty, narrows the default service set
+	// to services enabled by these compose profiles instead of every
+	// service in the project.
+	Profiles []string `json:"profiles,omitempty"`
+	// BuildArgs are passed as repeated `--build-arg KEY=VALUE` flags to
+	// every service build. A per-service build target isn't something
+	// `docker compose build` takes as a flag; set it in the compose
+	// file's `build.target` instead.
+	BuildArgs map[string]string `json:"build_args,omitempty"`
+	// Pull maps to `--pull`, always attempting to pull a newer base
+	// image even if one is already cached locally.
+	Pull bool `json:"pull,omitempty"`
+}
+
+// envVarKey matches a valid environment variable name, the syntax
+// docker's `-e`/`--build-arg` flags expect on the left of '='.
+var envVarKey = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// validateBuildArgs rejects build arg keys that aren't valid environment
+// variable identifiers, since docker would otherwise fail the whole
+// build with a less helpful error.
+func validateBuildArgs(args map[string]string) error {
+	for k := range args {
+		if !envVarKey.MatchString(k) {
+			return fmt.Errorf("invalid build arg key %q: must be a valid environment variable name", k)
+		}
+	}
+	return nil
+}
+
+// validateEnvKeys rejects env map keys that aren't valid environment
+// variable identifiers, the same check validateBuildArgs does for
+// --build-arg.
+func validateEnvKeys(env map[string]string) error {
+	for k := range env {
+		if !envVarKey.MatchString(k) {
+			return fmt.Errorf("invalid env key %q: must be a valid environment variable name", k)
+		}
+	}
+	return nil
+}
+
+// ServiceBuildResult reports the outcome of building a single service.
+type ServiceBuildResult struct {
+	Service  string `json:"service"`
+	Success  bool   `json:"success"`
+	Duration string `json:"duration"`
+	CacheHit bool   `json:"cacheHit"`
+	Error    string `json:"error,omitempty"`
+}
+
+// BuildResult is the structured result of compose_build.
+type BuildResult struct {
+	Services []ServiceBuildResult `json:"services"`
+}
+
+// Build builds the requested services. When params.Parallel is set, it
+// computes a dependency-respecting build order from the project's
+// depends_on graph and builds each wave concurrently, up to
+// params.MaxWorkers at a time.
+func (s *Service) Build(ctx context.Context, workDir string, params BuildParams) (*BuildResult, error) {
+	services := params.Services
+	if len(services) == 0 {
+		if path, err := findComposeFile(workDir); err == nil {
+			if names, err := s.configCache.ServiceNames(path); err == nil {
+				services = names
+			}
+			if len(params.Profiles) > 0 {
+				if data, readErr := os.ReadFile(path); readErr == nil {
+					if serviceProfiles, parseErr := parseProfiles(data); parseErr == nil {
+						services = servicesInProfiles(serviceProfiles, services, params.Profiles)
+					}
+				}
+			}
+		}
+	}
+	waves := [][]string{services}
+
+	if params.Parallel {
+		if path, err := findComposeFile(workDir); err == nil {
+			if data, readErr := os.ReadFile(path); readErr == nil {
+				if graph, parseErr := parseDependencyGraph(data); parseErr == nil {
+					if len(services) > 0 {
+						graph = filterGraph(graph, services)
+					}
+					if computed, waveErr := buildWaves(graph); waveErr == nil && len(computed) > 0 {
+						waves = computed
+					}
+				}
+			}
+		}
+	}
+
+	workers := params.MaxWorkers
+	if workers <= 0 {
+		workers = 4
+	}
+
+	result := &BuildResult{}
+	for _, wave := range waves {
+		if len(wave) == 0 {
+			continue
+		}
+		if !params.Parallel || len(wave) == 1 {
+			for _, svc := range wave {
+				result.Services = append(result.Services, s.buildOne(ctx, svc, params.BuildArgs, params.Pull))
+			}
+			continue
+		}
+
+		exec := executor.New(workers)
+		jobs := make([]executor.Job, 0, len(wave))
+		for _, svc := range wave {
+			svc := svc
+			jobs = append(jobs, executor.Job{
+				ID: svc,
+				Fn: func(ctx context.Context) (interface{}, error) {
+					r := s.buildOne(ctx, svc, params.BuildArgs, params.Pull)
+					return r, nil
+				},
+			})
+		}
+		for _, r := range exec.RunAll(jobs) {
+			result.Services = append(result.Services, r.Value.(ServiceBuildResult))
+		}
+		exec.Shutdown()
+	}
+
+	return result, nil
+}
+
+func (s *Service) buildOne(ctx context.Context, service string, buildArgs map[string]string, pull bool) ServiceBuildResult {
+	start := time.Now()
+
+	args := []string{"build"}
+	if pull {
+		args = append(args, "--pull")
+	}
+	keys := make([]string, 0, len(buildArgs))
+	for k := range buildArgs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		args = append(args, "--build-arg", k+"="+buildArgs[k])
+	}
+	args = append(args, service)
+
+	stdout, stderr, _, err := s.repo.Run(ctx, args)
+	output := stdout + stderr
+
+	r := ServiceBuildResult{
+		Service:  service,
+		Success:  err == nil,
+		Duration: time.Since(start).String(),
+		CacheHit: strings.Contains(output, "CACHED"),
+	}
+	if err != nil {
+		r.Error = err.Error()
+	}
+	return r
+}
+
+// filterGraph restricts graph to the requested services and their
+// transitive dependencies.
+func filterGraph(graph map[string][]string, services []string) map[string][]string {
+	keep := make(map[string]bool)
+	var visit func(string)
+	visit = func(svc string) {
+		if keep[svc] {
+			return
+		}
+		keep[svc] = true
+		for _, dep := range graph[svc] {
+			visit(dep)
+		}
+	}
+	for _, svc := range services {
+		visit(svc)
+	}
+
+	filtered := make(map[string][]string, len(keep))
+	for svc := range keep {
+		filtered[svc] = graph[svc]
+	}
+	return filtered
+}
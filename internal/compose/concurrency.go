@@ -0,0 +1,54 @@
+package compose
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// defaultConcurrencyLimit applies when no config overrides it, matching
+// the default used for parallel build workers.
+const defaultConcurrencyLimit = 4
+
+// ConcurrencyLimiter bounds how many compose subprocesses can run at
+// once, so a burst of tool calls can't spawn more `docker compose`
+// processes than the host can handle. Callers that can't get a slot
+// immediately block in Acquire until one frees or ctx is canceled.
+type ConcurrencyLimiter struct {
+	sem    chan struct{}
+	queued int32
+}
+
+// NewConcurrencyLimiter creates a ConcurrencyLimiter allowing at most
+// limit concurrent holders. A non-positive limit falls back to
+// defaultConcurrencyLimit.
+func NewConcurrencyLimiter(limit int) *ConcurrencyLimiter {
+	if limit <= 0 {
+		limit = defaultConcurrencyLimit
+	}
+	return &ConcurrencyLimiter{sem: make(chan struct{}, limit)}
+}
+
+// Acquire blocks until a slot is free or ctx is canceled, whichever
+// comes first.
+func (c *ConcurrencyLimiter) Acquire(ctx context.Context) error {
+	atomic.AddInt32(&c.queued, 1)
+	defer atomic.AddInt32(&c.queued, -1)
+
+	select {
+	case c.sem <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Release frees the slot acquired by a prior successful Acquire call.
+func (c *ConcurrencyLimiter) Release() {
+	<-c.sem
+}
+
+// Status reports how many slots are currently held and how many callers
+// are blocked waiting for one.
+func (c *ConcurrencyLimiter) Status() (active, queued int) {
+	return len(c.sem), int(atomic.LoadInt32(&c.queued))
+}
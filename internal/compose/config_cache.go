@@ -0,0 +1,216 @@
+package compose
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ConfigCache caches the service names declared by a compose file, keyed
+// by path, so repeated lookups (project discovery, build graphs) don't
+// re-read and re-parse the file unless it has changed on disk. If
+// constructed with a state path, the cache survives server restarts the
+// same way workspace.Manager persists the working directory.
+type ConfigCache struct {
+	mu        sync.RWMutex
+	entries   map[string]cacheEntry
+	statePath string
+	hits      int64
+	misses    int64
+}
+
+// CacheStats summarizes how effective the ConfigCache has been since the
+// server started.
+type CacheStats struct {
+	Entries  int     `json:"entries"`
+	Hits     int64   `json:"hits"`
+	Misses   int64   `json:"misses"`
+	HitRatio float64 `json:"hitRatio"`
+}
+
+type cacheEntry struct {
+	ModTime  time.Time `json:"modTime"`
+	Services []string  `json:"services"`
+}
+
+// persistedCache is the on-disk form of a ConfigCache.
+type persistedCache struct {
+	Entries map[string]cacheEntry `json:"entries"`
+}
+
+// NewConfigCache creates a ConfigCache, reloading any entries persisted
+// at statePath by a previous run. An empty statePath disables
+// persistence; a stale entry is simply re-parsed on its next lookup
+// since ServiceNames always validates the file's mtime before trusting
+// a cached entry.
+func NewConfigCache(statePath string) *ConfigCache {
+	c := &ConfigCache{entries: make(map[string]cacheEntry), statePath: statePath}
+	if statePath == "" {
+		return c
+	}
+	if data, err := os.ReadFile(statePath); err == nil {
+		var persisted persistedCache
+		if json.Unmarshal(data, &persisted) == nil && persisted.Entries != nil {
+			c.entries = persisted.Entries
+		}
+	}
+	return c
+}
+
+// ServiceNames returns the service names declared in the compose file at
+// path, reusing a cached result if the file hasn't been modified since
+// it was last parsed.
+func (c *ConfigCache) ServiceNames(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.RLock()
+	entry, ok := c.entries[path]
+	c.mu.RUnlock()
+	if ok && entry.ModTime.Equal(info.ModTime()) {
+		atomic.AddInt64(&c.hits, 1)
+		return entry.Services, nil
+	}
+	atomic.AddInt64(&c.misses, 1)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	services := c.extractNames(data)
+
+	c.mu.Lock()
+	c.entries[path] = cacheEntry{ModTime: info.ModTime(), Services: services}
+	// A persistence failure only costs a future cache miss, not this
+	// lookup's correctness, so it isn't returned as an error here.
+	c.persist()
+	c.mu.Unlock()
+
+	return services, nil
+}
+
+// Stats reports the cache's current entry count and its hit ratio since
+// the server started, so operators can judge whether caching is worth
+// enabling for their workspace.
+func (c *ConfigCache) Stats() CacheStats {
+	c.mu.RLock()
+	entries := len(c.entries)
+	c.mu.RUnlock()
+
+	hits := atomic.LoadInt64(&c.hits)
+	misses := atomic.LoadInt64(&c.misses)
+
+	var ratio float64
+	if total := hits + misses; total > 0 {
+		ratio = float64(hits) / float64(total)
+	}
+
+	return CacheStats{
+		Entries:  entries,
+		Hits:     hits,
+		Misses:   misses,
+		HitRatio: ratio,
+	}
+}
+
+// persist writes the cache to statePath atomically, via a temp file +
+// rename. The caller must hold c.mu. A disabled (empty statePath) cache
+// is a no-op.
+func (c *ConfigCache) persist() error {
+	if c.statePath == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(c.statePath), 0o755); err != nil {
+		return fmt.Errorf("create state dir: %w", err)
+	}
+
+	data, err := json.Marshal(persistedCache{Entries: c.entries})
+	if err != nil {
+		return fmt.Errorf("marshal config cache: %w", err)
+	}
+
+	tmp := c.statePath + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("write temp state: %w", err)
+	}
+	return os.Rename(tmp, c.statePath)
+}
+
+var quotedKey = regexp.MustCompile(`^["']?([a-zA-Z0-9_.-]+)["']?\s*:`)
+
+// extractNames parses the top-level `services:` mapping of a compose
+// file and returns its keys. Unlike a fixed two-space-indent regex, it
+// detects the actual indentation used by the file's first service entry
+// and tracks it for the rest of the block, so compose files indented
+// with tabs or more than two spaces are still parsed correctly. It
+// skips comments and blank lines, and stops at the first line that
+// returns to the `services:` key's own indentation or shallower.
+func (c *ConfigCache) extractNames(data []byte) []string {
+	lines := strings.Split(string(data), "\n")
+
+	servicesIndent := -1
+	serviceEntryIndent := -1
+	var names []string
+
+	for _, raw := range lines {
+		line := stripComment(raw)
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		indent := indentOf(line)
+		trimmed := strings.TrimSpace(line)
+
+		if servicesIndent == -1 {
+			if trimmed == "services:" {
+				servicesIndent = indent
+			}
+			continue
+		}
+
+		if serviceEntryIndent == -1 {
+			if indent <= servicesIndent {
+				break
+			}
+			serviceEntryIndent = indent
+		}
+
+		if indent < serviceEntryIndent {
+			break
+		}
+		if indent > serviceEntryIndent {
+			continue
+		}
+
+		if m := quotedKey.FindStringSubmatch(trimmed); m != nil {
+			names = append(names, m[1])
+		}
+	}
+
+	return names
+}
+
+func stripComment(line string) string {
+	if idx := strings.Index(line, "#"); idx != -1 {
+		return line[:idx]
+	}
+	return line
+}
+
+func indentOf(line string) int {
+	n := 0
+	for _, r := range line {
+		if r != ' ' && r != '\t' {
+			break
+		}
+		n++
+	}
+	return n
+}
@@ -0,0 +1,99 @@
+package compose
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeFile(t *testing.T, path, contents string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
+
+// touchLater rewrites path with new contents and backdates its mtime to
+// be distinct from whatever it was, since some filesystems have mtime
+// resolution too coarse for two writes in the same test to differ.
+func touchLater(t *testing.T, path, contents string) {
+	t.Helper()
+	writeFile(t, path, contents)
+	future := time.Now().Add(time.Minute)
+	if err := os.Chtimes(path, future, future); err != nil {
+		t.Fatalf("chtimes %s: %v", path, err)
+	}
+}
+
+func TestConfigCacheServiceNames(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "docker-compose.yml")
+	writeFile(t, path, "services:\n  web:\n    image: nginx\n  db:\n    image: postgres\n")
+
+	c := NewConfigCache("")
+	names, err := c.ServiceNames(path)
+	if err != nil {
+		t.Fatalf("ServiceNames: %v", err)
+	}
+	if len(names) != 2 || names[0] != "web" || names[1] != "db" {
+		t.Fatalf("names = %v, want [web db]", names)
+	}
+
+	stats := c.Stats()
+	if stats.Misses != 1 || stats.Hits != 0 {
+		t.Fatalf("stats after first lookup = %+v, want one miss", stats)
+	}
+
+	if _, err := c.ServiceNames(path); err != nil {
+		t.Fatalf("ServiceNames (cached): %v", err)
+	}
+	stats = c.Stats()
+	if stats.Hits != 1 {
+		t.Fatalf("stats after second lookup = %+v, want one hit", stats)
+	}
+}
+
+func TestConfigCachePersistsAcrossRestarts(t *testing.T) {
+	dir := t.TempDir()
+	composePath := filepath.Join(dir, "docker-compose.yml")
+	writeFile(t, composePath, "services:\n  web:\n    image: nginx\n")
+	statePath := filepath.Join(dir, "state", "config-cache.json")
+
+	first := NewConfigCache(statePath)
+	if _, err := first.ServiceNames(composePath); err != nil {
+		t.Fatalf("ServiceNames: %v", err)
+	}
+
+	second := NewConfigCache(statePath)
+	if _, err := second.ServiceNames(composePath); err != nil {
+		t.Fatalf("ServiceNames (restarted): %v", err)
+	}
+	if stats := second.Stats(); stats.Hits != 1 || stats.Misses != 0 {
+		t.Fatalf("stats after restart = %+v, want the persisted entry to be reused as a hit", stats)
+	}
+}
+
+func TestConfigCacheInvalidatesOnModification(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "docker-compose.yml")
+	writeFile(t, path, "services:\n  web:\n    image: nginx\n")
+
+	c := NewConfigCache("")
+	if _, err := c.ServiceNames(path); err != nil {
+		t.Fatalf("ServiceNames: %v", err)
+	}
+
+	touchLater(t, path, "services:\n  web:\n    image: nginx\n  worker:\n    image: busybox\n")
+
+	names, err := c.ServiceNames(path)
+	if err != nil {
+		t.Fatalf("ServiceNames (after modification): %v", err)
+	}
+	if len(names) != 2 {
+		t.Fatalf("names = %v, want the re-parsed two services", names)
+	}
+	if stats := c.Stats(); stats.Misses != 2 {
+		t.Fatalf("stats = %+v, want a second miss after modification", stats)
+	}
+}
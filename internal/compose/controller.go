@@ -0,0 +1,740 @@
+package compose
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/jontolof/docker-compose-mcp/internal/config"
+	"github.com/jontolof/docker-compose-mcp/internal/filter"
+	"github.com/jontolof/docker-compose-mcp/internal/mcp"
+	"github.com/jontolof/docker-compose-mcp/internal/session"
+	"github.com/jontolof/docker-compose-mcp/internal/workspace"
+)
+
+// Controller adapts the compose Service to the MCP tool interface,
+// translating tool call params into service calls and service results
+// into MCP content.
+type Controller struct {
+	service  *Service
+	ws       *workspace.Manager
+	sessions *session.Manager
+	cfg      *config.Config
+}
+
+// NewController creates a Controller backed by service, running compose
+// commands that need direct filesystem access (such as compose_build's
+// dependency graph) against ws's current directory. cfg governs which
+// commands and paths handlers are allowed to touch.
+func NewController(service *Service, ws *workspace.Manager, cfg *config.Config) *Controller {
+	var sessionTimeout time.Duration
+	var maxSessions int
+	if cfg != nil {
+		sessionTimeout = time.Duration(cfg.SessionTimeout) * time.Second
+		maxSessions = cfg.MaxSessions
+	}
+	c := &Controller{service: service, ws: ws, sessions: session.NewManager(sessionTimeout, maxSessions), cfg: cfg}
+	service.RegisterBuilder("compose_up", buildUpArgs)
+	service.RegisterBuilder("compose_down", buildDownArgs)
+	service.RegisterBuilder("compose_pull", buildPullArgs)
+	service.RegisterBuilder("compose_exec", buildExecArgs)
+	service.RegisterBuilder("compose_run", buildRunArgs)
+	service.RegisterBuilder("compose_top", buildTopArgs)
+	service.RegisterBuilder("compose_logs", buildLogsArgs)
+	service.RegisterBuilder("compose_cp", buildCpArgs)
+	service.RegisterBuilder("compose_images", buildImagesArgs)
+	service.RegisterBuilder("compose_port", buildPortArgs)
+	service.RegisterBuilder("compose_ps", buildPsArgs)
+	service.RegisterBuilder("compose_rm", buildRmArgs)
+	service.RegisterBuilder("compose_db_backup", buildDbBackupArgs)
+	service.RegisterBuilder("compose_migrate", buildMigrateArgs)
+	return c
+}
+
+// dir returns the directory compose commands should currently run in.
+func (c *Controller) dir() string {
+	return c.ws.Dir()
+}
+
+// Sessions exposes the controller's session manager, primarily so tools
+// outside the compose package can report its metrics.
+func (c *Controller) Sessions() *session.Manager {
+	return c.sessions
+}
+
+// Close stops the controller's background session reaper. It does not
+// stop sessions that are still running; callers that also want those
+// stopped should call compose_session_stop or let the server's shutdown
+// drain handle the underlying commands.
+func (c *Controller) Close() {
+	c.sessions.Close()
+}
+
+// Tools returns the MCP tool definitions and handlers this controller
+// implements.
+func (c *Controller) Tools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Definition: mcp.ToolDefinition{
+				Name:        "compose_up",
+				Description: "Start Docker Compose services with filtered output.",
+				InputSchema: json.RawMessage(`{
+					"type": "object",
+					"properties": {
+						"services": {"type": "array", "items": {"type": "string"}},
+						"detach": {"type": "boolean"},
+						"parallel": {"type": "boolean", "description": "Start services in dependency-ordered waves computed from depends_on, running each wave concurrently."},
+						"maxWorkers": {"type": "integer", "description": "Maximum services to start concurrently within a wave."},
+						"dryRun": {"type": "boolean", "description": "Return the docker command and working directory that would run, without executing it."},
+						"wait": {"type": "boolean", "description": "Block until every started service reports healthy instead of returning right after -d. Implies detach. Falls back to polling compose ps if the installed compose CLI predates --wait."},
+						"waitTimeout": {"type": "integer", "description": "Seconds to wait for services to become healthy before giving up. Defaults to 60."},
+						"timeout": {"type": "string", "description": "Override the configured command timeout for this call, e.g. \"30s\" or \"2m\". Clamped to the configured maximum."},
+						"env_file": {"description": "Path(s) to an env file to pass as --env-file, applied before the project's own .env; repeatable, later files override earlier ones.", "oneOf": [{"type": "string"}, {"type": "array", "items": {"type": "string"}}]},
+						"project_name": {"description": "Project name to pass as -p, overriding the config-level default derived from the working directory.", "type": "string"},
+						"profiles": {"type": "array", "items": {"type": "string"}, "description": "Also start services enabled by these compose profiles. Use compose_profiles to see what's available."}
+					}
+				}`),
+			},
+			Handler: c.handleUp,
+		},
+		{
+			Definition: mcp.ToolDefinition{
+				Name:        "compose_replay",
+				Description: "Re-run the most recently executed compose command, optionally overriding some of its parameters.",
+				InputSchema: json.RawMessage(`{
+					"type": "object",
+					"properties": {
+						"preferFailed": {"type": "boolean", "description": "Replay the last failed command instead of the last command."},
+						"overrides": {"type": "object", "description": "Param fields to replace in the replayed command."}
+					}
+				}`),
+			},
+			Handler: c.handleReplay,
+		},
+		{
+			Definition: mcp.ToolDefinition{
+				Name:        "compose_build",
+				Description: "Build Docker Compose services, optionally in dependency-ordered parallel waves.",
+				InputSchema: json.RawMessage(`{
+					"type": "object",
+					"properties": {
+						"services": {"type": "array", "items": {"type": "string"}},
+						"parallel": {"type": "boolean", "description": "Build independent services concurrently, respecting depends_on ordering."},
+						"maxWorkers": {"type": "integer", "description": "Maximum services to build concurrently within a wave."},
+						"profiles": {"type": "array", "items": {"type": "string"}, "description": "When services is omitted, build only services enabled by these compose profiles instead of every service."},
+						"build_args": {"type": "object", "additionalProperties": {"type": "string"}, "description": "Passed as repeated --build-arg KEY=VALUE flags. Keys must be valid environment variable names."},
+						"pull": {"type": "boolean", "description": "Always attempt to pull a newer base image, via --pull."}
+					}
+				}`),
+			},
+			Handler: c.handleBuild,
+		},
+		{
+			Definition: mcp.ToolDefinition{
+				Name:        "compose_queue_status",
+				Description: "Report pending and running operations in the serialized compose command queue.",
+				InputSchema: json.RawMessage(`{"type": "object", "properties": {}}`),
+			},
+			Handler: c.handleQueueStatus,
+		},
+		{
+			Definition: mcp.ToolDefinition{
+				Name:        "compose_concurrency_status",
+				Description: "Report how many compose subprocesses are currently running and how many tool calls are blocked waiting for a free worker slot.",
+				InputSchema: json.RawMessage(`{"type": "object", "properties": {}}`),
+			},
+			Handler: c.handleConcurrencyStatus,
+		},
+		{
+			Definition: mcp.ToolDefinition{
+				Name:        "compose_down",
+				Description: "Stop and remove Docker Compose services, optionally snapshotting volume data before removing it.",
+				InputSchema: json.RawMessage(`{
+					"type": "object",
+					"properties": {
+						"volumes": {"type": "boolean", "description": "Also remove named volumes."},
+						"snapshotVolumes": {"type": "boolean", "description": "Back up volume contents to .mcp-snapshots before removing them. Only applies when volumes is true."},
+						"confirm": {"type": "boolean", "description": "Required to be true alongside volumes, since removing volumes permanently deletes their data."},
+						"dryRun": {"type": "boolean", "description": "Return the docker command and working directory that would run, without executing it."},
+						"timeout": {"type": "string", "description": "Override the configured command timeout for this call, e.g. \"30s\" or \"2m\". Clamped to the configured maximum."},
+						"stopTimeout": {"type": "integer", "description": "Shutdown grace period in seconds, passed as -t."},
+						"rmi": {"type": "string", "enum": ["local", "all"], "description": "Remove images used by services after they stop: local for images without a custom tag, all for every image."},
+						"services": {"type": "array", "items": {"type": "string"}, "description": "Only stop and remove these services instead of the whole project."},
+						"env_file": {"description": "Path(s) to an env file to pass as --env-file, applied before the project's own .env; repeatable, later files override earlier ones.", "oneOf": [{"type": "string"}, {"type": "array", "items": {"type": "string"}}]},
+						"project_name": {"description": "Project name to pass as -p, overriding the config-level default derived from the working directory.", "type": "string"},
+						"profiles": {"type": "array", "items": {"type": "string"}, "description": "Only stop services enabled by these compose profiles. Use compose_profiles to see what's available."}
+					}
+				}`),
+			},
+			Handler: c.handleDown,
+		},
+		{
+			Definition: mcp.ToolDefinition{
+				Name:        "compose_volume_restore",
+				Description: "Restore a Docker volume's contents from a snapshot created by compose_down.",
+				InputSchema: json.RawMessage(`{
+					"type": "object",
+					"properties": {
+						"volume": {"type": "string", "description": "Name of the Docker volume to restore into."},
+						"snapshot": {"type": "string", "description": "Snapshot file name (relative to .mcp-snapshots) or an absolute path."}
+					},
+					"required": ["volume", "snapshot"]
+				}`),
+			},
+			Handler: c.handleVolumeRestore,
+		},
+		{
+			Definition: mcp.ToolDefinition{
+				Name:        "compose_pull",
+				Description: "Pull the latest images for services ahead of starting them.",
+				InputSchema: json.RawMessage(`{
+					"type": "object",
+					"properties": {
+						"services": {"type": "array", "items": {"type": "string"}},
+						"quiet": {"type": "boolean"},
+						"dryRun": {"type": "boolean", "description": "Return the docker command and working directory that would run, without executing it."},
+						"timeout": {"type": "string", "description": "Override the configured command timeout for this call, e.g. \"30s\" or \"2m\". Clamped to the configured maximum."},
+						"env_file": {"description": "Path(s) to an env file to pass as --env-file, applied before the project's own .env; repeatable, later files override earlier ones.", "oneOf": [{"type": "string"}, {"type": "array", "items": {"type": "string"}}]},
+						"project_name": {"description": "Project name to pass as -p, overriding the config-level default derived from the working directory.", "type": "string"}
+					}
+				}`),
+			},
+			Handler: c.simpleHandler("compose_pull"),
+		},
+		{
+			Definition: mcp.ToolDefinition{
+				Name:        "compose_exec",
+				Description: "Execute a command inside a running service container.",
+				InputSchema: json.RawMessage(`{
+					"type": "object",
+					"properties": {
+						"service": {"type": "string"},
+						"command": {"type": "string", "description": "Shell-style command line, e.g. \"sh -c 'echo hi'\"."},
+						"args": {"type": "array", "items": {"type": "string"}, "description": "Command and arguments passed verbatim, bypassing command-line parsing."},
+						"dryRun": {"type": "boolean", "description": "Return the docker command and working directory that would run, without executing it."},
+						"timeout": {"type": "string", "description": "Override the configured command timeout for this call, e.g. \"30s\" or \"2m\". Clamped to the configured maximum."},
+						"env_file": {"description": "Path(s) to an env file to pass as --env-file, applied before the project's own .env; repeatable, later files override earlier ones.", "oneOf": [{"type": "string"}, {"type": "array", "items": {"type": "string"}}]},
+						"project_name": {"description": "Project name to pass as -p, overriding the config-level default derived from the working directory.", "type": "string"},
+						"env": {"type": "object", "additionalProperties": {"type": "string"}, "description": "Environment variables to set in the exec'd process, passed as repeated -e KEY=VALUE flags before the service name."},
+						"detach": {"type": "boolean", "description": "Run the command in the background and return immediately with docker's acknowledgement instead of waiting for it to finish."}
+					},
+					"required": ["service"]
+				}`),
+			},
+			Handler: c.simpleHandler("compose_exec"),
+		},
+		{
+			Definition: mcp.ToolDefinition{
+				Name:        "compose_run",
+				Description: "Run a one-off command in a new container for a service, separate from its normal `up` invocation.",
+				InputSchema: json.RawMessage(`{
+					"type": "object",
+					"properties": {
+						"service": {"type": "string"},
+						"command": {"type": "string", "description": "Shell-style command line, e.g. \"sh -c 'echo hi'\"."},
+						"args": {"type": "array", "items": {"type": "string"}, "description": "Command and arguments passed verbatim, bypassing command-line parsing."},
+						"rm": {"type": "boolean", "description": "Remove the container after it exits."},
+						"noDeps": {"type": "boolean", "description": "Don't start linked services."},
+						"dryRun": {"type": "boolean", "description": "Return the docker command and working directory that would run, without executing it."},
+						"timeout": {"type": "string", "description": "Override the configured command timeout for this call, e.g. \"30s\" or \"2m\". Clamped to the configured maximum."},
+						"env_file": {"description": "Path(s) to an env file to pass as --env-file, applied before the project's own .env; repeatable, later files override earlier ones.", "oneOf": [{"type": "string"}, {"type": "array", "items": {"type": "string"}}]},
+						"project_name": {"description": "Project name to pass as -p, overriding the config-level default derived from the working directory.", "type": "string"}
+					},
+					"required": ["service"]
+				}`),
+			},
+			Handler: c.simpleHandler("compose_run"),
+		},
+		{
+			Definition: mcp.ToolDefinition{
+				Name:        "compose_top",
+				Description: "Show the running processes for one or more services.",
+				InputSchema: json.RawMessage(`{
+					"type": "object",
+					"properties": {
+						"services": {"type": "array", "items": {"type": "string"}},
+						"dryRun": {"type": "boolean", "description": "Return the docker command and working directory that would run, without executing it."},
+						"timeout": {"type": "string", "description": "Override the configured command timeout for this call, e.g. \"30s\" or \"2m\". Clamped to the configured maximum."},
+						"env_file": {"description": "Path(s) to an env file to pass as --env-file, applied before the project's own .env; repeatable, later files override earlier ones.", "oneOf": [{"type": "string"}, {"type": "array", "items": {"type": "string"}}]},
+						"project_name": {"description": "Project name to pass as -p, overriding the config-level default derived from the working directory.", "type": "string"}
+					}
+				}`),
+			},
+			Handler: c.simpleHandler("compose_top"),
+		},
+		{
+			Definition: mcp.ToolDefinition{
+				Name:        "compose_logs",
+				Description: "Show service logs. Set follow to stream them into a background session instead of returning a fixed snapshot.",
+				InputSchema: json.RawMessage(`{
+					"type": "object",
+					"properties": {
+						"services": {"type": "array", "items": {"type": "string"}},
+						"follow": {"type": "boolean", "description": "Stream logs into a background session; read it with compose_session_read."},
+						"tail": {"type": "string", "description": "Number of lines to show from the end of the logs, e.g. \"100\"."},
+						"since": {"type": "string", "description": "Show logs since this timestamp or relative duration, e.g. \"2024-01-01T00:00:00\" or \"10m\"."},
+						"until": {"type": "string", "description": "Show logs until this timestamp or relative duration."},
+						"timestamps": {"type": "boolean", "description": "Prefix each log line with its timestamp."},
+						"dryRun": {"type": "boolean", "description": "Return the docker command and working directory that would run, without executing it."},
+						"timeout": {"type": "string", "description": "Override the configured command timeout for this call, e.g. \"30s\" or \"2m\". Clamped to the configured maximum."},
+						"env_file": {"description": "Path(s) to an env file to pass as --env-file, applied before the project's own .env; repeatable, later files override earlier ones.", "oneOf": [{"type": "string"}, {"type": "array", "items": {"type": "string"}}]},
+						"project_name": {"description": "Project name to pass as -p, overriding the config-level default derived from the working directory.", "type": "string"}
+					}
+				}`),
+			},
+			Handler: c.handleLogs,
+		},
+		{
+			Definition: mcp.ToolDefinition{
+				Name:        "compose_watch",
+				Description: "Start `docker compose watch` for the given services in the background. Returns a sessionId; use compose_session_read to stream its output.",
+				InputSchema: json.RawMessage(`{
+					"type": "object",
+					"properties": {
+						"services": {"type": "array", "items": {"type": "string"}}
+					}
+				}`),
+			},
+			Handler: c.handleWatch,
+		},
+		{
+			Definition: mcp.ToolDefinition{
+				Name:        "compose_session_read",
+				Description: "Read output produced since the last read from a background session (e.g. compose_watch).",
+				InputSchema: json.RawMessage(`{
+					"type": "object",
+					"properties": {
+						"sessionId": {"type": "string"},
+						"cursor": {"type": "integer", "description": "Byte offset returned by the previous read; 0 to read from the start."}
+					},
+					"required": ["sessionId"]
+				}`),
+			},
+			Handler: c.handleSessionRead,
+		},
+		{
+			Definition: mcp.ToolDefinition{
+				Name:        "compose_session_stop",
+				Description: "Stop a background session started by compose_watch or a similar tool.",
+				InputSchema: json.RawMessage(`{
+					"type": "object",
+					"properties": {
+						"sessionId": {"type": "string"}
+					},
+					"required": ["sessionId"]
+				}`),
+			},
+			Handler: c.handleSessionStop,
+		},
+		{
+			Definition: mcp.ToolDefinition{
+				Name:        "compose_session_list",
+				Description: "List active and recently finished background sessions (compose_watch and similar long-running tools).",
+				InputSchema: json.RawMessage(`{"type": "object", "properties": {}}`),
+			},
+			Handler: c.handleSessionList,
+		},
+		{
+			Definition: mcp.ToolDefinition{
+				Name:        "compose_session_metrics",
+				Description: "Report session activity: how many background sessions were created, how many are still active, their average lifetime, and how many were stopped by the idle timeout rather than explicitly. Useful for spotting sessions that leak.",
+				InputSchema: json.RawMessage(`{"type": "object", "properties": {}}`),
+			},
+			Handler: c.handleSessionMetrics,
+		},
+		{
+			Definition: mcp.ToolDefinition{
+				Name:        "compose_events",
+				Description: "Stream Docker Compose lifecycle events (container create/start/die) for a stack. Use compose_events_read with the returned sessionId to consume them.",
+				InputSchema: json.RawMessage(`{
+					"type": "object",
+					"properties": {
+						"services": {"type": "array", "items": {"type": "string"}},
+						"since": {"type": "string"},
+						"until": {"type": "string"}
+					}
+				}`),
+			},
+			Handler: c.handleEvents,
+		},
+		{
+			Definition: mcp.ToolDefinition{
+				Name:        "compose_events_read",
+				Description: "Read and summarize the Docker Compose events produced since cursor by a compose_events session.",
+				InputSchema: json.RawMessage(`{
+					"type": "object",
+					"properties": {
+						"sessionId": {"type": "string"},
+						"cursor": {"type": "integer"}
+					},
+					"required": ["sessionId"]
+				}`),
+			},
+			Handler: c.handleEventsRead,
+		},
+		{
+			Definition: mcp.ToolDefinition{
+				Name:        "compose_events_stop",
+				Description: "Stop a compose_events session and terminate its subprocess.",
+				InputSchema: json.RawMessage(`{
+					"type": "object",
+					"properties": {
+						"sessionId": {"type": "string"}
+					},
+					"required": ["sessionId"]
+				}`),
+			},
+			Handler: c.handleSessionStop,
+		},
+		{
+			Definition: mcp.ToolDefinition{
+				Name:        "compose_cp",
+				Description: "Copy files or directories between a service container and the host. Exactly one of source or destination must use \"service:path\" syntax; the other is a host path.",
+				InputSchema: json.RawMessage(`{
+					"type": "object",
+					"properties": {
+						"source": {"type": "string"},
+						"destination": {"type": "string"},
+						"service": {"type": "string", "description": "Service name to prefix onto whichever of source/destination is still a bare host path."},
+						"index": {"type": "integer", "description": "Container replica index to copy from or to, for scaled services."},
+						"dryRun": {"type": "boolean", "description": "Return the docker command and working directory that would run, without executing it."},
+						"timeout": {"type": "string", "description": "Override the configured command timeout for this call, e.g. \"30s\" or \"2m\". Clamped to the configured maximum."},
+						"env_file": {"description": "Path(s) to an env file to pass as --env-file, applied before the project's own .env; repeatable, later files override earlier ones.", "oneOf": [{"type": "string"}, {"type": "array", "items": {"type": "string"}}]},
+						"project_name": {"description": "Project name to pass as -p, overriding the config-level default derived from the working directory.", "type": "string"}
+					},
+					"required": ["source", "destination"]
+				}`),
+			},
+			Handler: c.handleCp,
+		},
+		{
+			Definition: mcp.ToolDefinition{
+				Name:        "compose_images",
+				Description: "List the images used by the project's services, including tags and sizes.",
+				InputSchema: json.RawMessage(`{
+					"type": "object",
+					"properties": {
+						"services": {"type": "array", "items": {"type": "string"}},
+						"quiet": {"type": "boolean", "description": "Only print image IDs."}
+					}
+				}`),
+			},
+			Handler: c.simpleHandler("compose_images"),
+		},
+		{
+			Definition: mcp.ToolDefinition{
+				Name:        "compose_port",
+				Description: "Resolve the host address a service's container port is published on.",
+				InputSchema: json.RawMessage(`{
+					"type": "object",
+					"properties": {
+						"service": {"type": "string"},
+						"private_port": {"type": "integer", "description": "The container-side port to look up."},
+						"protocol": {"type": "string", "enum": ["tcp", "udp"], "description": "Defaults to tcp."},
+						"index": {"type": "integer", "description": "Container replica index, for scaled services."}
+					},
+					"required": ["service", "private_port"]
+				}`),
+			},
+			Handler: c.simpleHandler("compose_port"),
+		},
+		{
+			Definition: mcp.ToolDefinition{
+				Name:        "compose_profiles",
+				Description: "List the compose profiles declared by the project and which services belong to each.",
+				InputSchema: json.RawMessage(`{"type": "object", "properties": {}}`),
+			},
+			Handler: c.handleProfiles,
+		},
+		{
+			Definition: mcp.ToolDefinition{
+				Name:        "compose_project_info",
+				Description: "Summarize the project's compose file: service/network/volume counts, whether any service builds an image, and a rough complexity rating.",
+				InputSchema: json.RawMessage(`{"type": "object", "properties": {}}`),
+			},
+			Handler: c.handleProjectInfo,
+		},
+		{
+			Definition: mcp.ToolDefinition{
+				Name:        "compose_rm",
+				Description: "Remove stopped service containers, leaving networks and volumes intact (unlike compose_down). Requires force=true since compose rm otherwise prompts interactively.",
+				InputSchema: json.RawMessage(`{
+					"type": "object",
+					"properties": {
+						"services": {"type": "array", "items": {"type": "string"}},
+						"force": {"type": "boolean", "description": "Must be true to actually remove containers."},
+						"stop": {"type": "boolean", "description": "Stop the services first instead of failing if they're still running."},
+						"volumes": {"type": "boolean", "description": "Also remove the services' anonymous volumes."}
+					},
+					"required": ["force"]
+				}`),
+			},
+			Handler: c.handleRm,
+		},
+		{
+			Definition: mcp.ToolDefinition{
+				Name:        "compose_db_backup",
+				Description: "Dump or restore a service's database inside its container via compose exec.",
+				InputSchema: json.RawMessage(`{
+					"type": "object",
+					"properties": {
+						"service": {"type": "string"},
+						"action": {"type": "string", "enum": ["create", "restore", "list"]},
+						"db_type": {"type": "string", "enum": ["postgres", "mysql", "mongodb"], "description": "Selects the default dump/restore commands: postgres (default, needs POSTGRES_USER/POSTGRES_DB), mysql (needs MYSQL_USER/MYSQL_PASSWORD/MYSQL_DATABASE), or mongodb (needs MONGO_URI). Ignored when backupCommand/restoreCommand is set."},
+						"path": {"type": "string", "description": "Backup file path inside the container. Required for create and restore."},
+						"backupDir": {"type": "string", "description": "Directory listed by action list. Defaults to /backups."},
+						"backupCommand": {"type": "string", "description": "Override the default create-action dump invocation, run verbatim via sh -c. Disables compress and the integrity check."},
+						"restoreCommand": {"type": "string", "description": "Override the default restore-action invocation, run verbatim via sh -c."},
+						"compress": {"type": "boolean", "description": "Pipe or flag the default create command's dump through gzip, appending .gz to path if needed. Restore always auto-detects a .gz path and decompresses."},
+						"format": {"type": "string", "enum": ["raw", "structured"], "description": "For action list: \"raw\" (default) returns ls -la's output verbatim; \"structured\" parses it into a backups array of {name, size, modified}."}
+					},
+					"required": ["service", "action"]
+				}`),
+			},
+			Handler: c.handleDbBackup,
+		},
+		{
+			Definition: mcp.ToolDefinition{
+				Name:        "compose_migrate",
+				Description: "Run a database migration tool inside a service's container via compose exec, or query its current version and pending count.",
+				InputSchema: json.RawMessage(`{
+					"type": "object",
+					"properties": {
+						"service": {"type": "string"},
+						"action": {"type": "string", "enum": ["run", "status"], "description": "Defaults to run. status reports the current version and pending count via the tool's own status command, plus a parsed status object when migrate_tool is recognized."},
+						"migrate_tool": {"type": "string", "enum": ["migrate", "alembic", "flyway", "rails"], "description": "Selects the argument template used to build the command. Leave unset to fall back to naive direction substitution on migrateCommand (action run) or to running migrateCommand verbatim (action status)."},
+						"direction": {"type": "string", "enum": ["up", "down"], "description": "Defaults to up."},
+						"steps": {"type": "integer", "description": "Limit how many migrations to apply or roll back. Ignored by tools/directions that don't support it, and by target."},
+						"target": {"type": "string", "description": "Migrate to a specific version/revision instead of up-to-latest or down-one-step. Takes precedence over steps."},
+						"migrateCommand": {"type": "string", "description": "Base command run when migrate_tool is unset: direction is inserted via naive string substitution on the word \"migrate\". Required in that case."}
+					},
+					"required": ["service"]
+				}`),
+			},
+			Handler: c.handleMigrate,
+		},
+		{
+			Definition: mcp.ToolDefinition{
+				Name:        "compose_ps",
+				Description: "Show the status of project services. Set format to \"json\" for a structured array instead of filtered text.",
+				InputSchema: json.RawMessage(`{
+					"type": "object",
+					"properties": {
+						"services": {"type": "array", "items": {"type": "string"}},
+						"format": {"type": "string", "enum": ["json"], "description": "Return a structured [{name, state, health, ports, image}] array instead of filtered text."}
+					}
+				}`),
+			},
+			Handler: c.handlePs,
+		},
+	}
+}
+
+func (c *Controller) handleQueueStatus(ctx context.Context, args json.RawMessage) (*mcp.CallToolResult, error) {
+	pending, running := c.service.Queue().Status()
+	payload, err := json.Marshal(struct {
+		Pending []QueuedOperation `json:"pending"`
+		Running *QueuedOperation  `json:"running"`
+	}{Pending: pending, Running: running})
+	if err != nil {
+		return nil, fmt.Errorf("marshal queue status: %w", err)
+	}
+	return mcp.TextResult(string(payload)), nil
+}
+
+func (c *Controller) handleConcurrencyStatus(ctx context.Context, args json.RawMessage) (*mcp.CallToolResult, error) {
+	active, queued := c.service.Concurrency().Status()
+	payload, err := json.Marshal(struct {
+		Active int `json:"active"`
+		Queued int `json:"queued"`
+	}{Active: active, Queued: queued})
+	if err != nil {
+		return nil, fmt.Errorf("marshal concurrency status: %w", err)
+	}
+	return mcp.TextResult(string(payload)), nil
+}
+
+func buildUpArgs(params json.RawMessage) ([]string, error) {
+	var p UpParams
+	if len(params) > 0 {
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, fmt.Errorf("invalid compose_up params: %w", err)
+		}
+	}
+	args := profileArgs(p.Profiles)
+	args = append(args, "up")
+	if p.Detach || p.Wait {
+		args = append(args, "-d")
+	}
+	if p.Wait {
+		args = append(args, "--wait")
+		if p.WaitTimeout > 0 {
+			args = append(args, "--wait-timeout", strconv.Itoa(p.WaitTimeout))
+		}
+	}
+	args = append(args, p.Services...)
+	return args, nil
+}
+
+func (c *Controller) handleUp(ctx context.Context, args json.RawMessage) (*mcp.CallToolResult, error) {
+	var p UpParams
+	if len(args) > 0 {
+		if err := json.Unmarshal(args, &p); err != nil {
+			return nil, fmt.Errorf("invalid compose_up params: %w", err)
+		}
+	}
+
+	if err := c.service.validateProfiles(c.dir(), p.Profiles); err != nil {
+		return mcp.ErrorResult(err.Error()), nil
+	}
+
+	if p.DryRun && !p.Parallel {
+		result, err := c.service.Execute(ctx, "compose_up", args)
+		if result == nil {
+			return nil, err
+		}
+		return marshalResult(true, result)
+	}
+
+	var upResult *UpResult
+	if p.Parallel {
+		var err error
+		upResult, err = c.service.Up(ctx, c.dir(), p)
+		if upResult == nil {
+			return nil, err
+		}
+	} else {
+		result, err := c.service.Execute(ctx, "compose_up", args)
+		if result == nil {
+			return nil, err
+		}
+
+		if p.Wait && needsWaitFallback(result.Output, err) {
+			plain := p
+			plain.Wait = false
+			plain.WaitTimeout = 0
+			plainArgs, marshalErr := json.Marshal(plain)
+			if marshalErr != nil {
+				return nil, fmt.Errorf("rebuild compose_up params without wait: %w", marshalErr)
+			}
+			result, err = c.service.Execute(ctx, "compose_up", plainArgs)
+			if result == nil {
+				return nil, err
+			}
+			upResult = classifyUpResult(p.Services, result.Output, err)
+			upResult.Healthy, upResult.TimedOut = c.service.waitForHealthy(ctx, upResult.Succeeded, waitTimeoutDuration(p.WaitTimeout))
+		} else {
+			upResult = classifyUpResult(p.Services, result.Output, err)
+			if p.Wait {
+				upResult.Healthy = upResult.Succeeded
+				upResult.TimedOut = failedServiceNames(upResult.Failed)
+			}
+		}
+	}
+
+	success := (len(upResult.Failed) == 0 || len(upResult.Succeeded) > 0) && len(upResult.TimedOut) == 0
+	return marshalResult(success, upResult)
+}
+
+func (c *Controller) handlePs(ctx context.Context, args json.RawMessage) (*mcp.CallToolResult, error) {
+	var p PsParams
+	if len(args) > 0 {
+		if err := json.Unmarshal(args, &p); err != nil {
+			return nil, fmt.Errorf("invalid compose_ps params: %w", err)
+		}
+	}
+
+	result, err := c.service.Execute(ctx, "compose_ps", args)
+	if result == nil {
+		return nil, err
+	}
+	if p.Format != "json" {
+		return toolResult(result, err)
+	}
+
+	statuses, parseErr := ParsePsJSON(filter.FilterJSONLines(result.Output, psJSONKeepKeys))
+	if parseErr != nil {
+		return nil, fmt.Errorf("parse compose ps output: %w", parseErr)
+	}
+	return marshalResult(err == nil, statuses)
+}
+
+// simpleHandler returns an mcp.Handler that executes tool through the
+// service and reports its CommandResult as-is, for tools with no
+// result shaping beyond success/failure.
+func (c *Controller) simpleHandler(tool string) mcp.Handler {
+	return func(ctx context.Context, args json.RawMessage) (*mcp.CallToolResult, error) {
+		result, err := c.service.Execute(ctx, tool, args)
+		return toolResult(result, err)
+	}
+}
+
+func (c *Controller) handleReplay(ctx context.Context, args json.RawMessage) (*mcp.CallToolResult, error) {
+	var p ReplayParams
+	if len(args) > 0 {
+		if err := json.Unmarshal(args, &p); err != nil {
+			return nil, fmt.Errorf("invalid compose_replay params: %w", err)
+		}
+	}
+
+	result, err := c.service.Replay(ctx, p.PreferFailed, p.Overrides)
+	return toolResult(result, err)
+}
+
+func (c *Controller) handleBuild(ctx context.Context, args json.RawMessage) (*mcp.CallToolResult, error) {
+	var p BuildParams
+	if len(args) > 0 {
+		if err := json.Unmarshal(args, &p); err != nil {
+			return nil, fmt.Errorf("invalid compose_build params: %w", err)
+		}
+	}
+
+	if err := c.service.validateProfiles(c.dir(), p.Profiles); err != nil {
+		return mcp.ErrorResult(err.Error()), nil
+	}
+	if err := validateBuildArgs(p.BuildArgs); err != nil {
+		return mcp.ErrorResult(err.Error()), nil
+	}
+
+	result, err := c.service.Build(ctx, c.dir(), p)
+	if err != nil {
+		return nil, err
+	}
+
+	success := true
+	for _, svc := range result.Services {
+		if !svc.Success {
+			success = false
+			break
+		}
+	}
+	return marshalResult(success, result)
+}
+
+// toolResult converts a CommandResult into an MCP tool result, surfacing
+// command failures as error results rather than transport errors.
+func toolResult(result *CommandResult, err error) (*mcp.CallToolResult, error) {
+	if result == nil {
+		return nil, err
+	}
+	return marshalResult(err == nil, result)
+}
+
+// marshalResult JSON-encodes v and wraps it as an MCP tool result,
+// reporting it as an error result (IsError: true) when success is
+// false. Every compose handler uses this so MCP clients can distinguish
+// command failure from success by inspecting the result the same way
+// regardless of which tool they called, instead of each handler
+// re-deriving its own error-result logic.
+func marshalResult(success bool, v interface{}) (*mcp.CallToolResult, error) {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("marshal result: %w", err)
+	}
+	if !success {
+		return mcp.ErrorResult(string(payload)), nil
+	}
+	return mcp.TextResult(string(payload)), nil
+}
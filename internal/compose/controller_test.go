@@ -0,0 +1,62 @@
+package compose
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestMarshalResultSuccess(t *testing.T) {
+	result, err := marshalResult(true, CommandResult{Tool: "compose_ps", Output: "ok", ExitCode: 0, Success: true})
+	if err != nil {
+		t.Fatalf("marshalResult: %v", err)
+	}
+	if result.IsError {
+		t.Fatal("IsError = true, want false for a successful result")
+	}
+	var decoded CommandResult
+	if err := json.Unmarshal([]byte(result.Content[0].Text), &decoded); err != nil {
+		t.Fatalf("unmarshal payload: %v", err)
+	}
+	if decoded.Output != "ok" {
+		t.Fatalf("decoded.Output = %q, want %q", decoded.Output, "ok")
+	}
+}
+
+func TestMarshalResultFailurePreservesExitCode(t *testing.T) {
+	result, err := marshalResult(false, CommandResult{Tool: "compose_up", Output: "boom", ExitCode: 17, Success: false})
+	if err != nil {
+		t.Fatalf("marshalResult: %v", err)
+	}
+	if !result.IsError {
+		t.Fatal("IsError = false, want true for a failed result")
+	}
+	var decoded CommandResult
+	if err := json.Unmarshal([]byte(result.Content[0].Text), &decoded); err != nil {
+		t.Fatalf("unmarshal payload: %v", err)
+	}
+	if decoded.ExitCode != 17 {
+		t.Fatalf("decoded.ExitCode = %d, want 17", decoded.ExitCode)
+	}
+}
+
+func TestToolResultPropagatesNonCommandError(t *testing.T) {
+	wantErr := errors.New("boom")
+	result, err := toolResult(nil, wantErr)
+	if result != nil {
+		t.Fatalf("result = %+v, want nil when CommandResult is nil", result)
+	}
+	if err != wantErr {
+		t.Fatalf("err = %v, want %v", err, wantErr)
+	}
+}
+
+func TestToolResultMapsFailureToErrorResult(t *testing.T) {
+	result, err := toolResult(&CommandResult{Tool: "compose_logs", ExitCode: 1, Success: false}, errors.New("exit status 1"))
+	if err != nil {
+		t.Fatalf("toolResult: %v", err)
+	}
+	if !result.IsError {
+		t.Fatal("IsError = false, want true when the command returned an error")
+	}
+}
@@ -0,0 +1,96 @@
+package compose
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jontolof/docker-compose-mcp/internal/mcp"
+)
+
+// CpParams are the arguments to the compose_cp tool. Source and
+// Destination each name either a host path or, using Docker's
+// "service:path" syntax, a path inside a service container. Service and
+// Index are a convenience for callers that would rather not embed the
+// service name in the path themselves: when Service is set, it's
+// prefixed onto whichever side doesn't already look like a
+// "service:path" reference.
+type CpParams struct {
+	Source      string `json:"source"`
+	Destination string `json:"destination"`
+	Service     string `json:"service,omitempty"`
+	Index       int    `json:"index,omitempty"`
+}
+
+// hasServiceRef reports whether path uses Docker Compose's
+// "service:path" syntax rather than naming a plain host path.
+func hasServiceRef(path string) bool {
+	idx := strings.Index(path, ":")
+	return idx > 0
+}
+
+// resolveCpPaths applies p.Service to whichever side is still a bare
+// host path and confirms the result has exactly one service:path side,
+// since `docker compose cp` only accepts a container path on one end of
+// the copy.
+func resolveCpPaths(p CpParams) (source, destination string, err error) {
+	source, destination = p.Source, p.Destination
+	if p.Service != "" {
+		switch {
+		case !hasServiceRef(source) && !hasServiceRef(destination):
+			source = p.Service + ":" + source
+		case hasServiceRef(source) && hasServiceRef(destination):
+			return "", "", fmt.Errorf("service only applies to the side of the copy that's a host path")
+		}
+	}
+	if hasServiceRef(source) == hasServiceRef(destination) {
+		return "", "", fmt.Errorf("exactly one of source or destination must use service:path syntax")
+	}
+	return source, destination, nil
+}
+
+func buildCpArgs(params json.RawMessage) ([]string, error) {
+	var p CpParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, fmt.Errorf("invalid compose_cp params: %w", err)
+	}
+	if p.Source == "" || p.Destination == "" {
+		return nil, fmt.Errorf("source and destination are required")
+	}
+
+	source, destination, err := resolveCpPaths(p)
+	if err != nil {
+		return nil, err
+	}
+
+	args := []string{"cp"}
+	if p.Index > 0 {
+		args = append(args, "--index", strconv.Itoa(p.Index))
+	}
+	args = append(args, source, destination)
+	return args, nil
+}
+
+func (c *Controller) handleCp(ctx context.Context, args json.RawMessage) (*mcp.CallToolResult, error) {
+	var p CpParams
+	if err := json.Unmarshal(args, &p); err != nil {
+		return nil, fmt.Errorf("invalid compose_cp params: %w", err)
+	}
+
+	source, destination, err := resolveCpPaths(p)
+	if err != nil {
+		return nil, err
+	}
+	hostPath := source
+	if hasServiceRef(source) {
+		hostPath = destination
+	}
+	if c.cfg != nil && c.cfg.IsPathRestricted(hostPath) {
+		return mcp.ErrorResult(fmt.Sprintf("path %q is restricted", hostPath)), nil
+	}
+
+	result, err := c.service.Execute(ctx, "compose_cp", args)
+	return toolResult(result, err)
+}
@@ -0,0 +1,240 @@
+package compose
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jontolof/docker-compose-mcp/internal/mcp"
+)
+
+// defaultBackupDir is where compose_db_backup looks for backups when
+// BackupDir isn't set.
+const defaultBackupDir = "/backups"
+
+// dbBackupListSeparator delimits the fields structured list mode asks
+// find to print. It can't appear in a file size or timestamp and is
+// unlikely in a filename.
+const dbBackupListSeparator = "|"
+
+// Supported values for DbBackupParams.DbType. dbTypePostgres is the
+// default, kept for compatibility with callers that predate this field.
+const (
+	dbTypePostgres = "postgres"
+	dbTypeMySQL    = "mysql"
+	dbTypeMongoDB  = "mongodb"
+)
+
+// DbBackupParams are the arguments to the compose_db_backup tool, which
+// runs a database dump or restore inside a running service container via
+// `docker compose exec`.
+type DbBackupParams struct {
+	Service string `json:"service"`
+	// Action selects the operation: "create" dumps the database to Path,
+	// "restore" loads Path back into the database, "list" shows backups
+	// already present in BackupDir.
+	Action string `json:"action"`
+	// DbType selects the default dump/restore commands: "postgres" (the
+	// default) expects POSTGRES_USER and POSTGRES_DB in the container's
+	// environment; "mysql" expects MYSQL_USER, MYSQL_PASSWORD, and
+	// MYSQL_DATABASE; "mongodb" expects MONGO_URI. Ignored when
+	// BackupCommand/RestoreCommand is set.
+	DbType string `json:"db_type,omitempty"`
+	// Path is the backup file path inside the container, required for
+	// create and restore.
+	Path string `json:"path,omitempty"`
+	// BackupDir is listed by action "list". Defaults to defaultBackupDir.
+	BackupDir string `json:"backupDir,omitempty"`
+	// BackupCommand and RestoreCommand override the default pg_dump/psql
+	// invocations, run verbatim via `sh -c` in the container; Compress
+	// and the post-backup integrity check don't apply when set.
+	BackupCommand  string `json:"backupCommand,omitempty"`
+	RestoreCommand string `json:"restoreCommand,omitempty"`
+	// Compress pipes the default create command's dump through gzip,
+	// appending ".gz" to Path if it isn't already there. Restore always
+	// auto-detects a ".gz" Path and decompresses regardless of Compress.
+	Compress bool `json:"compress,omitempty"`
+	// Format selects how action "list" reports backups: "raw" (the
+	// default) returns `ls -la`'s output verbatim; "structured" parses
+	// it into a Backups array of {name, size, modified}.
+	Format string `json:"format,omitempty"`
+}
+
+// BackupEntry describes one backup file found by compose_db_backup's
+// structured list format.
+type BackupEntry struct {
+	Name     string `json:"name"`
+	Size     int64  `json:"size"`
+	Modified string `json:"modified"`
+}
+
+func buildDbBackupArgs(params json.RawMessage) ([]string, error) {
+	var p DbBackupParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, fmt.Errorf("invalid compose_db_backup params: %w", err)
+	}
+	if p.Service == "" {
+		return nil, fmt.Errorf("service is required")
+	}
+
+	command, err := dbBackupCommand(p)
+	if err != nil {
+		return nil, err
+	}
+	return []string{"exec", "-T", p.Service, "sh", "-c", command}, nil
+}
+
+// dbBackupCommand builds the shell command run inside the service
+// container for p.Action, preferring an explicit override command.
+func dbBackupCommand(p DbBackupParams) (string, error) {
+	backupDir := p.BackupDir
+	if backupDir == "" {
+		backupDir = defaultBackupDir
+	}
+
+	switch p.Action {
+	case "create":
+		if p.BackupCommand != "" {
+			return p.BackupCommand, nil
+		}
+		if p.Path == "" {
+			return "", fmt.Errorf("path is required for action create")
+		}
+		dump, out, err := defaultCreateCommand(p.DbType, p.Path, p.Compress)
+		if err != nil {
+			return "", err
+		}
+		// test -s fails the command (and so the tool call) if the dump
+		// came out empty, catching a silently broken dump before the
+		// caller trusts a worthless backup.
+		return fmt.Sprintf(`%s && test -s %s`, dump, shellQuote(out)), nil
+	case "restore":
+		if p.RestoreCommand != "" {
+			return p.RestoreCommand, nil
+		}
+		if p.Path == "" {
+			return "", fmt.Errorf("path is required for action restore")
+		}
+		return defaultRestoreCommand(p.DbType, p.Path)
+	case "list":
+		if p.Format == "structured" {
+			return fmt.Sprintf(`find %s -maxdepth 1 -type f -printf '%%f%s%%s%s%%TY-%%Tm-%%TdT%%TH:%%TM:%%TS\n'`,
+				shellQuote(backupDir), dbBackupListSeparator, dbBackupListSeparator), nil
+		}
+		return fmt.Sprintf(`ls -la %s`, shellQuote(backupDir)), nil
+	default:
+		return "", fmt.Errorf("invalid action %q: must be create, restore, or list", p.Action)
+	}
+}
+
+// defaultCreateCommand builds the default dump command for dbType, along
+// with the final output path (which gains a ".gz" suffix when compress
+// is set and it isn't already there).
+func defaultCreateCommand(dbType, path string, compress bool) (cmd, outPath string, err error) {
+	outPath = path
+	if compress && !strings.HasSuffix(outPath, ".gz") {
+		outPath += ".gz"
+	}
+
+	switch dbType {
+	case "", dbTypePostgres:
+		if compress {
+			return fmt.Sprintf(`pg_dump -U "$POSTGRES_USER" "$POSTGRES_DB" | gzip > %s`, shellQuote(outPath)), outPath, nil
+		}
+		return fmt.Sprintf(`pg_dump -U "$POSTGRES_USER" "$POSTGRES_DB" > %s`, shellQuote(outPath)), outPath, nil
+	case dbTypeMySQL:
+		if compress {
+			return fmt.Sprintf(`mysqldump -u "$MYSQL_USER" -p"$MYSQL_PASSWORD" "$MYSQL_DATABASE" | gzip > %s`, shellQuote(outPath)), outPath, nil
+		}
+		return fmt.Sprintf(`mysqldump -u "$MYSQL_USER" -p"$MYSQL_PASSWORD" "$MYSQL_DATABASE" > %s`, shellQuote(outPath)), outPath, nil
+	case dbTypeMongoDB:
+		// mongodump writes its own archive format rather than SQL text,
+		// so compression is a flag instead of a shell pipe.
+		cmd := fmt.Sprintf(`mongodump --uri="$MONGO_URI" --archive=%s`, shellQuote(outPath))
+		if compress {
+			cmd += " --gzip"
+		}
+		return cmd, outPath, nil
+	default:
+		return "", "", fmt.Errorf("invalid db_type %q: must be postgres, mysql, or mongodb", dbType)
+	}
+}
+
+// defaultRestoreCommand builds the default restore command for dbType,
+// auto-detecting a ".gz" suffix on path to decompress on the fly.
+func defaultRestoreCommand(dbType, path string) (string, error) {
+	gz := strings.HasSuffix(path, ".gz")
+
+	switch dbType {
+	case "", dbTypePostgres:
+		if gz {
+			return fmt.Sprintf(`gunzip -c %s | psql -U "$POSTGRES_USER" "$POSTGRES_DB"`, shellQuote(path)), nil
+		}
+		return fmt.Sprintf(`psql -U "$POSTGRES_USER" "$POSTGRES_DB" < %s`, shellQuote(path)), nil
+	case dbTypeMySQL:
+		if gz {
+			return fmt.Sprintf(`gunzip -c %s | mysql -u "$MYSQL_USER" -p"$MYSQL_PASSWORD" "$MYSQL_DATABASE"`, shellQuote(path)), nil
+		}
+		return fmt.Sprintf(`mysql -u "$MYSQL_USER" -p"$MYSQL_PASSWORD" "$MYSQL_DATABASE" < %s`, shellQuote(path)), nil
+	case dbTypeMongoDB:
+		cmd := fmt.Sprintf(`mongorestore --uri="$MONGO_URI" --archive=%s`, shellQuote(path))
+		if gz {
+			cmd += " --gzip"
+		}
+		return cmd, nil
+	default:
+		return "", fmt.Errorf("invalid db_type %q: must be postgres, mysql, or mongodb", dbType)
+	}
+}
+
+// shellQuote wraps s in single quotes for safe interpolation into a
+// `sh -c` command, escaping any single quote it already contains.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func (c *Controller) handleDbBackup(ctx context.Context, args json.RawMessage) (*mcp.CallToolResult, error) {
+	var p DbBackupParams
+	if err := json.Unmarshal(args, &p); err != nil {
+		return nil, fmt.Errorf("invalid compose_db_backup params: %w", err)
+	}
+
+	result, err := c.service.Execute(ctx, "compose_db_backup", args)
+	if err != nil || result == nil || !result.Success || p.Action != "list" || p.Format != "structured" {
+		return toolResult(result, err)
+	}
+
+	entries, parseErr := parseBackupEntries(result.Output)
+	if parseErr != nil {
+		return toolResult(result, err)
+	}
+	return marshalResult(true, struct {
+		*CommandResult
+		Backups []BackupEntry `json:"backups"`
+	}{CommandResult: result, Backups: entries})
+}
+
+// parseBackupEntries parses compose_db_backup's structured list output,
+// one dbBackupListSeparator-delimited "name|size|modified" record per
+// line.
+func parseBackupEntries(output string) ([]BackupEntry, error) {
+	output = strings.TrimSpace(output)
+	if output == "" {
+		return nil, nil
+	}
+	var entries []BackupEntry
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.SplitN(line, dbBackupListSeparator, 3)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("unexpected backup listing line: %q", line)
+		}
+		size, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("parse size in %q: %w", line, err)
+		}
+		entries = append(entries, BackupEntry{Name: fields[0], Size: size, Modified: fields[2]})
+	}
+	return entries, nil
+}
@@ -0,0 +1,101 @@
+package compose
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/jontolof/docker-compose-mcp/internal/mcp"
+)
+
+// DownParams are the arguments to the compose_down tool.
+type DownParams struct {
+	Volumes         bool `json:"volumes,omitempty"`
+	SnapshotVolumes bool `json:"snapshotVolumes,omitempty"`
+	// Confirm must be true alongside Volumes, since removing volumes
+	// permanently deletes their data. SnapshotVolumes is the safer
+	// alternative to an unconditional "yes, delete it" confirmation.
+	Confirm bool `json:"confirm,omitempty"`
+	// Profiles restricts down to services enabled by these compose
+	// profiles, same as compose_up's Profiles.
+	Profiles []string `json:"profiles,omitempty"`
+	// StopTimeout is the shutdown grace period in seconds, passed as -t.
+	// Distinct from the command-level "timeout" override every tool
+	// accepts (extractTimeout in dto.go), which bounds how long the MCP
+	// call itself may run.
+	StopTimeout int `json:"stopTimeout,omitempty"`
+	// Rmi removes images used by services after they stop: "local" for
+	// images without a custom tag, "all" for every image.
+	Rmi string `json:"rmi,omitempty"`
+	// Services limits down to specific services instead of the whole
+	// project.
+	Services []string `json:"services,omitempty"`
+}
+
+// DownResult is the structured result of compose_down.
+type DownResult struct {
+	Output    string           `json:"output"`
+	Success   bool             `json:"success"`
+	Snapshots []VolumeSnapshot `json:"snapshots,omitempty"`
+}
+
+func buildDownArgs(params json.RawMessage) ([]string, error) {
+	var p DownParams
+	if len(params) > 0 {
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, fmt.Errorf("invalid compose_down params: %w", err)
+		}
+	}
+	if p.Rmi != "" && p.Rmi != "local" && p.Rmi != "all" {
+		return nil, fmt.Errorf("invalid rmi %q: must be local or all", p.Rmi)
+	}
+
+	args := profileArgs(p.Profiles)
+	args = append(args, "down")
+	if p.Volumes {
+		args = append(args, "--volumes")
+	}
+	if p.StopTimeout > 0 {
+		args = append(args, "-t", strconv.Itoa(p.StopTimeout))
+	}
+	if p.Rmi != "" {
+		args = append(args, "--rmi", p.Rmi)
+	}
+	args = append(args, p.Services...)
+	return args, nil
+}
+
+func (c *Controller) handleDown(ctx context.Context, args json.RawMessage) (*mcp.CallToolResult, error) {
+	var p DownParams
+	if len(args) > 0 {
+		if err := json.Unmarshal(args, &p); err != nil {
+			return nil, fmt.Errorf("invalid compose_down params: %w", err)
+		}
+	}
+
+	if p.Volumes && !p.Confirm {
+		return mcp.ErrorResult("compose_down with volumes=true permanently deletes named volume data; retry with confirm=true (and consider snapshotVolumes=true to back them up first)"), nil
+	}
+
+	if err := c.service.validateProfiles(c.dir(), p.Profiles); err != nil {
+		return mcp.ErrorResult(err.Error()), nil
+	}
+
+	var snapshots []VolumeSnapshot
+	if p.Volumes && p.SnapshotVolumes {
+		snaps, err := snapshotAllVolumes(ctx, c.dir())
+		if err != nil {
+			return nil, fmt.Errorf("snapshot volumes before down: %w", err)
+		}
+		snapshots = snaps
+	}
+
+	result, err := c.service.Execute(ctx, "compose_down", args)
+	if result == nil {
+		return nil, err
+	}
+
+	down := DownResult{Output: result.Output, Success: result.Success, Snapshots: snapshots}
+	return marshalResult(down.Success, down)
+}
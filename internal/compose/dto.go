@@ -0,0 +1,171 @@
+package compose
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// UpParams are the arguments to the compose_up tool.
+type UpParams struct {
+	Services   []string `json:"services,omitempty"`
+	Detach     bool     `json:"detach,omitempty"`
+	Parallel   bool     `json:"parallel,omitempty"`
+	MaxWorkers int      `json:"maxWorkers,omitempty"`
+	DryRun     bool     `json:"dryRun,omitempty"`
+	// Wait blocks until every started service reports healthy, implying
+	// Detach. WaitTimeout bounds how long to wait, in seconds; 0 uses
+	// defaultWaitTimeout.
+	Wait        bool `json:"wait,omitempty"`
+	WaitTimeout int  `json:"waitTimeout,omitempty"`
+	// Profiles restricts the command to services enabled by these
+	// compose profiles, in addition to whatever Services names.
+	Profiles []string `json:"profiles,omitempty"`
+}
+
+// ReplayParams are the arguments to the compose_replay tool. Overrides is
+// a set of param keys to replace in the replayed command before
+// re-execution. PreferFailed selects the last failed command instead of
+// simply the last command.
+type ReplayParams struct {
+	PreferFailed bool                       `json:"preferFailed,omitempty"`
+	Overrides    map[string]json.RawMessage `json:"overrides,omitempty"`
+}
+
+// CommandResult is the structured outcome of a single compose invocation.
+type CommandResult struct {
+	Tool     string   `json:"tool"`
+	Args     []string `json:"args"`
+	Output   string   `json:"output"`
+	ExitCode int      `json:"exitCode"`
+	Success  bool     `json:"success"`
+	// ErrorKind classifies why the command failed, e.g. "daemon_down" or
+	// "permission_denied", so a client can react without parsing Output.
+	// Empty on success.
+	ErrorKind ErrorKind `json:"error_kind,omitempty"`
+	// DryRun and Command are only set when the call was short-circuited
+	// by dry-run mode: Command is the full `docker ...` line that would
+	// have run, and Dir is the working directory it would have run in.
+	DryRun  bool   `json:"dryRun,omitempty"`
+	Command string `json:"command,omitempty"`
+	Dir     string `json:"dir,omitempty"`
+}
+
+// dryRunParams extracts the dryRun field every compose tool accepts,
+// independent of its other params.
+type dryRunParams struct {
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// extractDryRun reports whether params requested dry-run mode.
+func extractDryRun(params json.RawMessage) bool {
+	if len(params) == 0 {
+		return false
+	}
+	var p dryRunParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		return false
+	}
+	return p.DryRun
+}
+
+// detachParams extracts the detach field accepted by tools that can run
+// fire-and-forget, independent of their other params.
+type detachParams struct {
+	Detach bool `json:"detach,omitempty"`
+}
+
+// extractDetach reports whether params requested detached (fire-and-forget)
+// execution.
+func extractDetach(params json.RawMessage) bool {
+	if len(params) == 0 {
+		return false
+	}
+	var p detachParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		return false
+	}
+	return p.Detach
+}
+
+// timeoutParams extracts the timeout field every compose tool accepts,
+// independent of its other params.
+type timeoutParams struct {
+	Timeout string `json:"timeout,omitempty"`
+}
+
+// extractTimeout parses the "timeout" field out of params as a Go
+// duration string (e.g. "30s", "2m"), returning ok as false if params
+// omits it or the value doesn't parse.
+func extractTimeout(params json.RawMessage) (d time.Duration, ok bool) {
+	if len(params) == 0 {
+		return 0, false
+	}
+	var p timeoutParams
+	if err := json.Unmarshal(params, &p); err != nil || p.Timeout == "" {
+		return 0, false
+	}
+	d, err := time.ParseDuration(p.Timeout)
+	if err != nil {
+		return 0, false
+	}
+	return d, true
+}
+
+// stringOrSlice unmarshals either a single JSON string or an array of
+// strings into a []string, so a param like env_file can accept either
+// form without the caller wrapping a single path in an array.
+type stringOrSlice []string
+
+func (s *stringOrSlice) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		if single != "" {
+			*s = []string{single}
+		}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*s = list
+	return nil
+}
+
+// projectNameParams extracts the project_name field every compose tool
+// accepts, independent of its other params.
+type projectNameParams struct {
+	ProjectName string `json:"project_name,omitempty"`
+}
+
+// extractProjectName pulls the "project_name" field out of params, if
+// set.
+func extractProjectName(params json.RawMessage) string {
+	if len(params) == 0 {
+		return ""
+	}
+	var p projectNameParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		return ""
+	}
+	return p.ProjectName
+}
+
+// envFileParams extracts the env_file field every compose tool accepts,
+// independent of its other params.
+type envFileParams struct {
+	EnvFile stringOrSlice `json:"env_file,omitempty"`
+}
+
+// extractEnvFiles pulls the "env_file" field out of params, accepting
+// either a single path or an array of paths.
+func extractEnvFiles(params json.RawMessage) []string {
+	if len(params) == 0 {
+		return nil
+	}
+	var p envFileParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil
+	}
+	return p.EnvFile
+}
@@ -0,0 +1,51 @@
+package compose
+
+import "regexp"
+
+// ErrorKind classifies a failed compose command by the likely cause,
+// read from its stderr, so a client can react programmatically (e.g.
+// prompt the user to start Docker) instead of pattern-matching the
+// message itself.
+type ErrorKind string
+
+const (
+	// ErrorKindDaemonDown means the Docker daemon wasn't reachable.
+	ErrorKindDaemonDown ErrorKind = "daemon_down"
+	// ErrorKindComposeNotFound means the compose plugin or binary isn't
+	// installed.
+	ErrorKindComposeNotFound ErrorKind = "compose_not_found"
+	// ErrorKindPermissionDenied means the caller lacks permission to
+	// talk to the Docker socket.
+	ErrorKindPermissionDenied ErrorKind = "permission_denied"
+	// ErrorKindComposeFileNotFound means no compose file was found in
+	// the working directory.
+	ErrorKindComposeFileNotFound ErrorKind = "compose_file_not_found"
+	// ErrorKindOther covers any failure that doesn't match a known
+	// pattern: a real command error, bad arguments, and so on.
+	ErrorKindOther ErrorKind = "other"
+)
+
+// errorKindPatterns maps each classifiable ErrorKind to the stderr
+// pattern that identifies it. Checked in order, so more specific
+// patterns should come before more general ones.
+var errorKindPatterns = []struct {
+	kind    ErrorKind
+	pattern *regexp.Regexp
+}{
+	{ErrorKindDaemonDown, regexp.MustCompile(`(?i)cannot connect to the docker daemon|is the docker daemon running|connection refused`)},
+	{ErrorKindComposeNotFound, regexp.MustCompile(`(?i)docker: 'compose' is not a docker command|executable file not found`)},
+	{ErrorKindPermissionDenied, regexp.MustCompile(`(?i)permission denied|dial unix .*: connect: permission denied`)},
+	{ErrorKindComposeFileNotFound, regexp.MustCompile(`(?i)no configuration file provided|no such file or directory.*(compose|docker-compose)\.ya?ml`)},
+}
+
+// classifyError reports the ErrorKind that best matches stderr, or
+// ErrorKindOther if nothing more specific matches. stderr may be empty,
+// e.g. when a command never ran at all.
+func classifyError(stderr string) ErrorKind {
+	for _, p := range errorKindPatterns {
+		if p.pattern.MatchString(stderr) {
+			return p.kind
+		}
+	}
+	return ErrorKindOther
+}
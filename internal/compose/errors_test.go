@@ -0,0 +1,55 @@
+package compose
+
+import "testing"
+
+func TestClassifyError(t *testing.T) {
+	cases := []struct {
+		name   string
+		stderr string
+		want   ErrorKind
+	}{
+		{
+			name:   "daemon down",
+			stderr: "Cannot connect to the Docker daemon at unix:///var/run/docker.sock. Is the docker daemon running?",
+			want:   ErrorKindDaemonDown,
+		},
+		{
+			name:   "connection refused",
+			stderr: "dial tcp 127.0.0.1:2375: connect: connection refused",
+			want:   ErrorKindDaemonDown,
+		},
+		{
+			name:   "compose not found",
+			stderr: "docker: 'compose' is not a docker command.",
+			want:   ErrorKindComposeNotFound,
+		},
+		{
+			name:   "permission denied",
+			stderr: "Got permission denied while trying to connect to the Docker daemon socket",
+			want:   ErrorKindPermissionDenied,
+		},
+		{
+			name:   "compose file not found",
+			stderr: "no configuration file provided: not found",
+			want:   ErrorKindComposeFileNotFound,
+		},
+		{
+			name:   "unmatched error",
+			stderr: "service \"web\" has neither an image nor a build context specified",
+			want:   ErrorKindOther,
+		},
+		{
+			name:   "empty stderr",
+			stderr: "",
+			want:   ErrorKindOther,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := classifyError(tc.stderr); got != tc.want {
+				t.Errorf("classifyError(%q) = %q, want %q", tc.stderr, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package compose
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jontolof/docker-compose-mcp/internal/mcp"
+)
+
+// EventsParams are the arguments to the compose_events tool.
+type EventsParams struct {
+	Services []string `json:"services,omitempty"`
+	Since    string   `json:"since,omitempty"`
+	Until    string   `json:"until,omitempty"`
+}
+
+func (c *Controller) handleEvents(ctx context.Context, args json.RawMessage) (*mcp.CallToolResult, error) {
+	var p EventsParams
+	if len(args) > 0 {
+		if err := json.Unmarshal(args, &p); err != nil {
+			return nil, fmt.Errorf("invalid compose_events params: %w", err)
+		}
+	}
+
+	cliArgs := []string{"compose", "events", "--json"}
+	if p.Since != "" {
+		cliArgs = append(cliArgs, "--since", p.Since)
+	}
+	if p.Until != "" {
+		cliArgs = append(cliArgs, "--until", p.Until)
+	}
+	cliArgs = append(cliArgs, p.Services...)
+
+	sess, err := c.sessions.Start(ctx, "compose_events", c.dir(), "docker", cliArgs)
+	if err != nil {
+		return nil, fmt.Errorf("start events session: %w", err)
+	}
+
+	payload, err := json.Marshal(WatchStarted{SessionID: sess.ID})
+	if err != nil {
+		return nil, err
+	}
+	return mcp.TextResult(string(payload)), nil
+}
+
+// EventsReadParams are the arguments to the compose_events_read tool.
+type EventsReadParams struct {
+	SessionID string `json:"sessionId"`
+	Cursor    int    `json:"cursor,omitempty"`
+}
+
+// EventSummary is a compact view of one `docker compose events --json`
+// line.
+type EventSummary struct {
+	Action    string `json:"action"`
+	Service   string `json:"service,omitempty"`
+	Container string `json:"container,omitempty"`
+	Time      string `json:"time,omitempty"`
+}
+
+// rawComposeEvent mirrors the fields of one `docker compose events
+// --json` line that EventSummary cares about.
+type rawComposeEvent struct {
+	Action  string `json:"action"`
+	Service string `json:"service"`
+	ID      string `json:"id"`
+	Time    string `json:"time"`
+}
+
+// EventsReadResult is the result of compose_events_read: the events
+// produced since cursor, summarized, and the cursor to pass next time.
+type EventsReadResult struct {
+	Events []EventSummary `json:"events"`
+	Cursor int            `json:"cursor"`
+	Status string         `json:"status"`
+	Error  string         `json:"error,omitempty"`
+}
+
+func (c *Controller) handleEventsRead(ctx context.Context, args json.RawMessage) (*mcp.CallToolResult, error) {
+	var p EventsReadParams
+	if err := json.Unmarshal(args, &p); err != nil {
+		return nil, fmt.Errorf("invalid compose_events_read params: %w", err)
+	}
+
+	sess, ok := c.sessions.Get(p.SessionID)
+	if !ok {
+		return nil, fmt.Errorf("unknown session %q", p.SessionID)
+	}
+
+	chunk, cursor := sess.ReadFrom(p.Cursor)
+	status, sessErr := sess.State()
+
+	result := EventsReadResult{Events: summarizeEvents(chunk), Cursor: cursor, Status: string(status)}
+	if sessErr != nil {
+		result.Error = sessErr.Error()
+	}
+	payload, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	return mcp.TextResult(string(payload)), nil
+}
+
+// summarizeEvents parses each line of chunk as a `docker compose events
+// --json` event, skipping lines that aren't valid JSON. A line split
+// across two reads by a mid-line cursor is simply dropped, which is an
+// acceptable trade-off for a lightweight streaming reader.
+func summarizeEvents(chunk string) []EventSummary {
+	var events []EventSummary
+	for _, line := range strings.Split(chunk, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		var raw rawComposeEvent
+		if err := json.Unmarshal([]byte(line), &raw); err != nil {
+			continue
+		}
+		events = append(events, EventSummary{
+			Action:    raw.Action,
+			Service:   raw.Service,
+			Container: raw.ID,
+			Time:      raw.Time,
+		})
+	}
+	return events
+}
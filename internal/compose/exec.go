@@ -0,0 +1,128 @@
+package compose
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ExecParams are the arguments to the compose_exec tool. Command is a
+// shell-style command line; Args, if given, are passed through verbatim
+// instead of being parsed from Command.
+type ExecParams struct {
+	Service string   `json:"service"`
+	Command string   `json:"command,omitempty"`
+	Args    []string `json:"args,omitempty"`
+	// Env is passed as repeated `-e KEY=VALUE` flags, set in the exec'd
+	// process before the command runs. Keys must be valid environment
+	// variable names.
+	Env map[string]string `json:"env,omitempty"`
+	// Detach maps to `-d`, running the command in the background and
+	// returning the exec's acknowledgement immediately instead of
+	// waiting for it to finish.
+	Detach bool `json:"detach,omitempty"`
+}
+
+func buildExecArgs(params json.RawMessage) ([]string, error) {
+	var p ExecParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, fmt.Errorf("invalid compose_exec params: %w", err)
+	}
+	if p.Service == "" {
+		return nil, fmt.Errorf("service is required")
+	}
+	if err := validateEnvKeys(p.Env); err != nil {
+		return nil, err
+	}
+
+	args := []string{"exec"}
+	if p.Detach {
+		args = append(args, "-d")
+	}
+	keys := make([]string, 0, len(p.Env))
+	for k := range p.Env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		args = append(args, "-e", k+"="+p.Env[k])
+	}
+	args = append(args, p.Service)
+	switch {
+	case len(p.Args) > 0:
+		args = append(args, p.Args...)
+	case p.Command != "":
+		parsed, err := splitCommandLine(p.Command)
+		if err != nil {
+			return nil, fmt.Errorf("parse command: %w", err)
+		}
+		args = append(args, parsed...)
+	default:
+		return nil, fmt.Errorf("command or args is required")
+	}
+	return args, nil
+}
+
+// splitCommandLine splits a shell-style command line into arguments,
+// honoring single and double quotes and backslash escapes so that
+// quoted arguments containing spaces aren't broken apart.
+func splitCommandLine(s string) ([]string, error) {
+	var (
+		args    []string
+		current strings.Builder
+		inWord  bool
+		quote   rune
+	)
+
+	flush := func() {
+		if inWord {
+			args = append(args, current.String())
+			current.Reset()
+			inWord = false
+		}
+	}
+
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		if quote != 0 {
+			if r == quote {
+				quote = 0
+				continue
+			}
+			if r == '\\' && quote == '"' && i+1 < len(runes) {
+				next := runes[i+1]
+				if next == '"' || next == '\\' {
+					current.WriteRune(next)
+					i++
+					continue
+				}
+			}
+			current.WriteRune(r)
+			continue
+		}
+
+		switch {
+		case r == '\'' || r == '"':
+			quote = r
+			inWord = true
+		case r == '\\' && i+1 < len(runes):
+			current.WriteRune(runes[i+1])
+			inWord = true
+			i++
+		case r == ' ' || r == '\t':
+			flush()
+		default:
+			current.WriteRune(r)
+			inWord = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	flush()
+	return args, nil
+}
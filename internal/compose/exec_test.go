@@ -0,0 +1,72 @@
+package compose
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestSplitCommandLine(t *testing.T) {
+	cases := []struct {
+		name    string
+		input   string
+		want    []string
+		wantErr bool
+	}{
+		{name: "empty", input: "", want: nil},
+		{name: "simple", input: "echo hello", want: []string{"echo", "hello"}},
+		{name: "double quoted", input: `sh -c "echo hello world"`, want: []string{"sh", "-c", "echo hello world"}},
+		{name: "single quoted", input: `echo 'hello world'`, want: []string{"echo", "hello world"}},
+		{name: "escaped space", input: `echo hello\ world`, want: []string{"echo", "hello world"}},
+		{name: "escaped quote in double quotes", input: `echo "say \"hi\""`, want: []string{"echo", `say "hi"`}},
+		{name: "unterminated quote", input: `echo "hello`, wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := splitCommandLine(tc.input)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("splitCommandLine(%q) = %v, want error", tc.input, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("splitCommandLine(%q) unexpected error: %v", tc.input, err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("splitCommandLine(%q) = %#v, want %#v", tc.input, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestBuildExecArgsEnv(t *testing.T) {
+	params, _ := json.Marshal(ExecParams{
+		Service: "web",
+		Command: "rails test",
+		Env:     map[string]string{"RAILS_ENV": "test", "DEBUG": "1"},
+	})
+
+	args, err := buildExecArgs(params)
+	if err != nil {
+		t.Fatalf("buildExecArgs: %v", err)
+	}
+
+	want := []string{"exec", "-e", "DEBUG=1", "-e", "RAILS_ENV=test", "web", "rails", "test"}
+	if !reflect.DeepEqual(args, want) {
+		t.Fatalf("buildExecArgs args = %#v, want %#v", args, want)
+	}
+}
+
+func TestBuildExecArgsInvalidEnvKey(t *testing.T) {
+	params, _ := json.Marshal(ExecParams{
+		Service: "web",
+		Command: "echo hi",
+		Env:     map[string]string{"not a key": "1"},
+	})
+
+	if _, err := buildExecArgs(params); err == nil {
+		t.Fatal("buildExecArgs with an invalid env key should fail")
+	}
+}
@@ -0,0 +1,86 @@
+package compose
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// defaultHistorySize is the number of recent commands retained for
+// inspection and replay.
+const defaultHistorySize = 50
+
+// HistoryEntry records a single executed compose command.
+type HistoryEntry struct {
+	Tool      string          `json:"tool"`
+	Args      []string        `json:"args"`
+	Params    json.RawMessage `json:"params"`
+	Timestamp time.Time       `json:"timestamp"`
+	ExitCode  int             `json:"exitCode"`
+	Success   bool            `json:"success"`
+}
+
+// History is a fixed-capacity ring buffer of recently executed commands,
+// newest last.
+type History struct {
+	mu      sync.Mutex
+	entries []HistoryEntry
+	size    int
+}
+
+// NewHistory creates a History that retains up to size entries. A size of
+// 0 uses the default capacity.
+func NewHistory(size int) *History {
+	if size <= 0 {
+		size = defaultHistorySize
+	}
+	return &History{size: size}
+}
+
+// Record appends entry to the history, evicting the oldest entry if the
+// buffer is full.
+func (h *History) Record(entry HistoryEntry) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.entries = append(h.entries, entry)
+	if len(h.entries) > h.size {
+		h.entries = h.entries[len(h.entries)-h.size:]
+	}
+}
+
+// Last returns the most recently recorded entry, or false if history is
+// empty.
+func (h *History) Last() (HistoryEntry, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.entries) == 0 {
+		return HistoryEntry{}, false
+	}
+	return h.entries[len(h.entries)-1], true
+}
+
+// LastFailed returns the most recently recorded failing entry, or false
+// if none exists in the retained window.
+func (h *History) LastFailed() (HistoryEntry, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for i := len(h.entries) - 1; i >= 0; i-- {
+		if !h.entries[i].Success {
+			return h.entries[i], true
+		}
+	}
+	return HistoryEntry{}, false
+}
+
+// All returns a copy of the retained history, oldest first.
+func (h *History) All() []HistoryEntry {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	out := make([]HistoryEntry, len(h.entries))
+	copy(out, h.entries)
+	return out
+}
@@ -0,0 +1,27 @@
+package compose
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ImagesParams are the arguments to the compose_images tool.
+type ImagesParams struct {
+	Services []string `json:"services,omitempty"`
+	Quiet    bool     `json:"quiet,omitempty"`
+}
+
+func buildImagesArgs(params json.RawMessage) ([]string, error) {
+	var p ImagesParams
+	if len(params) > 0 {
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, fmt.Errorf("invalid compose_images params: %w", err)
+		}
+	}
+	args := []string{"images"}
+	if p.Quiet {
+		args = append(args, "--quiet")
+	}
+	args = append(args, p.Services...)
+	return args, nil
+}
@@ -0,0 +1,82 @@
+package compose
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jontolof/docker-compose-mcp/internal/mcp"
+)
+
+// LogsParams are the arguments to the compose_logs tool. When Follow is
+// set, logs are streamed into a background session instead of being
+// collected and returned directly; use compose_session_read with the
+// returned sessionId to read them.
+type LogsParams struct {
+	Services   []string `json:"services,omitempty"`
+	Follow     bool     `json:"follow,omitempty"`
+	Tail       string   `json:"tail,omitempty"`
+	Since      string   `json:"since,omitempty"`
+	Until      string   `json:"until,omitempty"`
+	Timestamps bool     `json:"timestamps,omitempty"`
+}
+
+func buildLogsArgs(params json.RawMessage) ([]string, error) {
+	var p LogsParams
+	if len(params) > 0 {
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, fmt.Errorf("invalid compose_logs params: %w", err)
+		}
+	}
+
+	args := append([]string{"logs"}, logsFilterArgs(p)...)
+	args = append(args, p.Services...)
+	return args, nil
+}
+
+// logsFilterArgs builds the `docker compose logs` flags shared by
+// one-shot and follow invocations, excluding --follow itself.
+func logsFilterArgs(p LogsParams) []string {
+	var args []string
+	if p.Tail != "" {
+		args = append(args, "--tail", p.Tail)
+	}
+	if p.Since != "" {
+		args = append(args, "--since", p.Since)
+	}
+	if p.Until != "" {
+		args = append(args, "--until", p.Until)
+	}
+	if p.Timestamps {
+		args = append(args, "--timestamps")
+	}
+	return args
+}
+
+func (c *Controller) handleLogs(ctx context.Context, args json.RawMessage) (*mcp.CallToolResult, error) {
+	var p LogsParams
+	if len(args) > 0 {
+		if err := json.Unmarshal(args, &p); err != nil {
+			return nil, fmt.Errorf("invalid compose_logs params: %w", err)
+		}
+	}
+
+	if !p.Follow {
+		result, err := c.service.Execute(ctx, "compose_logs", args)
+		return toolResult(result, err)
+	}
+
+	cliArgs := append([]string{"compose", "logs", "--follow"}, logsFilterArgs(p)...)
+	cliArgs = append(cliArgs, p.Services...)
+
+	sess, err := c.sessions.Start(ctx, "compose_logs", c.dir(), "docker", cliArgs)
+	if err != nil {
+		return nil, fmt.Errorf("start logs session: %w", err)
+	}
+
+	payload, err := json.Marshal(WatchStarted{SessionID: sess.ID})
+	if err != nil {
+		return nil, err
+	}
+	return mcp.TextResult(string(payload)), nil
+}
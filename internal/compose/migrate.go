@@ -0,0 +1,333 @@
+package compose
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jontolof/docker-compose-mcp/internal/mcp"
+)
+
+// Supported values for MigrateParams.MigrateTool. An empty MigrateTool
+// falls back to naive direction substitution on MigrateCommand, which is
+// the only option for tools without a built-in template.
+const (
+	migrateToolGolangMigrate = "migrate"
+	migrateToolAlembic       = "alembic"
+	migrateToolFlyway        = "flyway"
+	migrateToolRails         = "rails"
+)
+
+// MigrateParams are the arguments to the compose_migrate tool, which runs
+// a database migration tool inside a running service container via
+// `docker compose exec`.
+type MigrateParams struct {
+	Service string `json:"service"`
+	// Action is "run" (the default) to apply or roll back migrations, or
+	// "status" to report the current version and pending count without
+	// changing anything.
+	Action string `json:"action,omitempty"`
+	// MigrateTool selects the argument template used to build the
+	// command: "migrate" (golang-migrate, expects MIGRATIONS_PATH and
+	// DATABASE_URL), "alembic", "flyway", or "rails". Leave unset to fall
+	// back to naive direction substitution on MigrateCommand.
+	MigrateTool string `json:"migrate_tool,omitempty"`
+	// Direction is "up" (the default) or "down".
+	Direction string `json:"direction,omitempty"`
+	// Steps limits how many migrations to apply or roll back. Ignored by
+	// tools/directions that don't support it (e.g. flyway, any Target).
+	Steps int `json:"steps,omitempty"`
+	// Target migrates to a specific version/revision instead of
+	// up-to-latest or down-one-step. Takes precedence over Steps.
+	Target string `json:"target,omitempty"`
+	// MigrateCommand is the base command run when MigrateTool is unset:
+	// the direction is naively inserted via strings.Replace(cmd,
+	// "migrate", "migrate "+direction, 1), matching the tool's own
+	// "migrate <direction>" invocation style. Required in that case.
+	MigrateCommand string `json:"migrateCommand,omitempty"`
+}
+
+func buildMigrateArgs(params json.RawMessage) ([]string, error) {
+	var p MigrateParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, fmt.Errorf("invalid compose_migrate params: %w", err)
+	}
+	if p.Service == "" {
+		return nil, fmt.Errorf("service is required")
+	}
+
+	var command string
+	var err error
+	switch p.Action {
+	case "", "run":
+		command, err = migrateCommand(p)
+	case "status":
+		command, err = migrateStatusCommand(p)
+	default:
+		return nil, fmt.Errorf("invalid action %q: must be run or status", p.Action)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return []string{"exec", "-T", p.Service, "sh", "-c", command}, nil
+}
+
+// migrateStatusCommand builds the shell command that reports the current
+// migration state for p.MigrateTool, run inside the service container.
+func migrateStatusCommand(p MigrateParams) (string, error) {
+	switch p.MigrateTool {
+	case migrateToolGolangMigrate:
+		return `migrate -path "$MIGRATIONS_PATH" -database "$DATABASE_URL" version`, nil
+	case migrateToolAlembic:
+		return "alembic current", nil
+	case migrateToolFlyway:
+		return "flyway info", nil
+	case migrateToolRails:
+		return "rails db:migrate:status", nil
+	case "":
+		if p.MigrateCommand == "" {
+			return "", fmt.Errorf("migrateCommand is required when migrate_tool is not set")
+		}
+		return p.MigrateCommand, nil
+	default:
+		return "", fmt.Errorf("invalid migrate_tool %q: must be migrate, alembic, flyway, or rails", p.MigrateTool)
+	}
+}
+
+// migrateCommand builds the shell command run inside the service
+// container for p, preferring MigrateTool's known argument template and
+// falling back to naive direction substitution on MigrateCommand for
+// unrecognized tools.
+func migrateCommand(p MigrateParams) (string, error) {
+	direction := p.Direction
+	if direction == "" {
+		direction = "up"
+	}
+	if direction != "up" && direction != "down" {
+		return "", fmt.Errorf("invalid direction %q: must be up or down", direction)
+	}
+
+	switch p.MigrateTool {
+	case migrateToolGolangMigrate:
+		return golangMigrateCommand(direction, p.Steps, p.Target), nil
+	case migrateToolAlembic:
+		return alembicCommand(direction, p.Steps, p.Target), nil
+	case migrateToolFlyway:
+		return flywayCommand(direction, p.Target), nil
+	case migrateToolRails:
+		return railsCommand(direction, p.Steps, p.Target), nil
+	case "":
+		if p.MigrateCommand == "" {
+			return "", fmt.Errorf("migrateCommand is required when migrate_tool is not set")
+		}
+		return strings.Replace(p.MigrateCommand, "migrate", "migrate "+direction, 1), nil
+	default:
+		return "", fmt.Errorf("invalid migrate_tool %q: must be migrate, alembic, flyway, or rails", p.MigrateTool)
+	}
+}
+
+// golangMigrateCommand builds a golang-migrate/migrate CLI invocation,
+// reading its migrations path and database URL from the container
+// environment.
+func golangMigrateCommand(direction string, steps int, target string) string {
+	base := `migrate -path "$MIGRATIONS_PATH" -database "$DATABASE_URL"`
+	if target != "" {
+		return fmt.Sprintf("%s goto %s", base, shellQuote(target))
+	}
+	cmd := fmt.Sprintf("%s %s", base, direction)
+	if steps > 0 {
+		cmd += fmt.Sprintf(" %d", steps)
+	}
+	return cmd
+}
+
+// alembicCommand builds an Alembic invocation. Alembic has no generic
+// "step count" flag; it expresses relative movement as a signed offset
+// from the current revision instead.
+func alembicCommand(direction string, steps int, target string) string {
+	sub := "upgrade"
+	if direction == "down" {
+		sub = "downgrade"
+	}
+	if target != "" {
+		return fmt.Sprintf("alembic %s %s", sub, shellQuote(target))
+	}
+	if direction == "up" {
+		if steps > 0 {
+			return fmt.Sprintf("alembic upgrade +%d", steps)
+		}
+		return "alembic upgrade head"
+	}
+	if steps > 0 {
+		return fmt.Sprintf("alembic downgrade -%d", steps)
+	}
+	return "alembic downgrade base"
+}
+
+// flywayCommand builds a Flyway invocation. Community Flyway has no
+// per-step rollback; "down" maps to undo, which reverts the single most
+// recently applied migration.
+func flywayCommand(direction, target string) string {
+	if direction == "down" {
+		return "flyway undo"
+	}
+	if target != "" {
+		return fmt.Sprintf("flyway -target=%s migrate", target)
+	}
+	return "flyway migrate"
+}
+
+// railsCommand builds a Rails db:migrate/db:rollback invocation.
+func railsCommand(direction string, steps int, target string) string {
+	if direction == "down" {
+		if target != "" {
+			return fmt.Sprintf("rails db:migrate:down VERSION=%s", target)
+		}
+		if steps > 0 {
+			return fmt.Sprintf("rails db:rollback STEP=%d", steps)
+		}
+		return "rails db:rollback"
+	}
+	if target != "" {
+		return fmt.Sprintf("rails db:migrate:up VERSION=%s", target)
+	}
+	return "rails db:migrate"
+}
+
+// MigrationStatus is the structured extraction action "status" produces
+// from a migration tool's own status command output.
+type MigrationStatus struct {
+	// Version is the currently applied migration's version/revision, as
+	// reported by the tool. Empty if none have been applied yet.
+	Version string `json:"version"`
+	// Pending is how many migrations haven't been applied yet. Not every
+	// tool's status command reports this directly; see the per-tool
+	// parse functions below for what's actually derived versus left 0.
+	Pending int `json:"pending"`
+}
+
+func (c *Controller) handleMigrate(ctx context.Context, args json.RawMessage) (*mcp.CallToolResult, error) {
+	var p MigrateParams
+	if err := json.Unmarshal(args, &p); err != nil {
+		return nil, fmt.Errorf("invalid compose_migrate params: %w", err)
+	}
+
+	result, err := c.service.Execute(ctx, "compose_migrate", args)
+	if err != nil || result == nil || !result.Success || p.Action != "status" {
+		return toolResult(result, err)
+	}
+
+	status, parseErr := parseMigrationStatus(p.MigrateTool, result.Output)
+	if parseErr != nil {
+		return toolResult(result, err)
+	}
+	return marshalResult(true, struct {
+		*CommandResult
+		Status *MigrationStatus `json:"status"`
+	}{CommandResult: result, Status: status})
+}
+
+// parseMigrationStatus extracts a MigrationStatus from tool's status
+// command output. Each tool's default output format is parsed on a
+// best-effort basis; unrecognized formats return an error rather than a
+// guess, leaving the caller with the raw output instead.
+func parseMigrationStatus(tool, output string) (*MigrationStatus, error) {
+	output = strings.TrimSpace(output)
+	switch tool {
+	case migrateToolGolangMigrate:
+		return parseGolangMigrateStatus(output)
+	case migrateToolAlembic:
+		return parseAlembicStatus(output)
+	case migrateToolFlyway:
+		return parseFlywayStatus(output)
+	case migrateToolRails:
+		return parseRailsStatus(output)
+	default:
+		return nil, fmt.Errorf("structured status parsing isn't supported for migrate_tool %q", tool)
+	}
+}
+
+// parseGolangMigrateStatus parses `migrate version`'s output, a single
+// line like "3" or "3 (dirty)". migrate's version command doesn't report
+// how many migrations are still pending, so Pending is always 0.
+func parseGolangMigrateStatus(output string) (*MigrationStatus, error) {
+	fields := strings.Fields(output)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("empty migrate version output")
+	}
+	return &MigrationStatus{Version: fields[0]}, nil
+}
+
+// parseAlembicStatus parses `alembic current`'s output, e.g.
+// "ae1027a6acf (head)" when up to date, or a bare revision id when
+// newer revisions exist upstream. Since current doesn't enumerate
+// pending revisions, Pending is 1 as a not-up-to-date flag rather than
+// an exact count.
+func parseAlembicStatus(output string) (*MigrationStatus, error) {
+	line := firstNonEmptyLine(output)
+	if line == "" {
+		return &MigrationStatus{}, nil
+	}
+	fields := strings.Fields(line)
+	status := &MigrationStatus{Version: fields[0]}
+	if !strings.Contains(line, "(head)") {
+		status.Pending = 1
+	}
+	return status, nil
+}
+
+// parseRailsStatus parses `rails db:migrate:status`'s table output,
+// counting "down" rows as pending and taking the highest "up" row's
+// version as current.
+func parseRailsStatus(output string) (*MigrationStatus, error) {
+	status := &MigrationStatus{}
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		switch strings.ToLower(fields[0]) {
+		case "up":
+			status.Version = fields[1]
+		case "down":
+			status.Pending++
+		}
+	}
+	return status, nil
+}
+
+// parseFlywayStatus parses `flyway info`'s pipe-delimited table,
+// counting "Pending" rows and taking the last "Success" row's version as
+// current.
+func parseFlywayStatus(output string) (*MigrationStatus, error) {
+	status := &MigrationStatus{}
+	for _, line := range strings.Split(output, "\n") {
+		cells := strings.Split(line, "|")
+		if len(cells) < 3 {
+			continue
+		}
+		for i := range cells {
+			cells[i] = strings.TrimSpace(cells[i])
+		}
+		state := cells[len(cells)-1]
+		switch state {
+		case "Success":
+			status.Version = cells[1]
+		case "Pending":
+			status.Pending++
+		}
+	}
+	return status, nil
+}
+
+// firstNonEmptyLine returns the first line of s with non-whitespace
+// content, or "" if every line is blank.
+func firstNonEmptyLine(s string) string {
+	for _, line := range strings.Split(s, "\n") {
+		if trimmed := strings.TrimSpace(line); trimmed != "" {
+			return trimmed
+		}
+	}
+	return ""
+}
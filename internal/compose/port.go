@@ -0,0 +1,38 @@
+package compose
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// PortParams are the arguments to the compose_port tool.
+type PortParams struct {
+	Service     string `json:"service"`
+	PrivatePort int    `json:"private_port"`
+	Protocol    string `json:"protocol,omitempty"`
+	Index       int    `json:"index,omitempty"`
+}
+
+func buildPortArgs(params json.RawMessage) ([]string, error) {
+	var p PortParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, fmt.Errorf("invalid compose_port params: %w", err)
+	}
+	if p.Service == "" {
+		return nil, fmt.Errorf("service is required")
+	}
+	if p.PrivatePort == 0 {
+		return nil, fmt.Errorf("private_port is required")
+	}
+
+	args := []string{"port"}
+	if p.Protocol != "" {
+		args = append(args, "--protocol", p.Protocol)
+	}
+	if p.Index > 0 {
+		args = append(args, "--index", strconv.Itoa(p.Index))
+	}
+	args = append(args, p.Service, strconv.Itoa(p.PrivatePort))
+	return args, nil
+}
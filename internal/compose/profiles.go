@@ -0,0 +1,104 @@
+package compose
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jontolof/docker-compose-mcp/internal/mcp"
+)
+
+// ProfilesResult lists the profiles declared by a compose file and
+// which services opt into each one.
+type ProfilesResult struct {
+	Profiles []string            `json:"profiles"`
+	Services map[string][]string `json:"services"`
+}
+
+// Profiles parses workDir's compose file and returns the set of
+// profiles it declares along with each service's profile membership.
+func (s *Service) Profiles(workDir string) (*ProfilesResult, error) {
+	path, err := findComposeFile(workDir)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read compose file: %w", err)
+	}
+	serviceProfiles, err := parseProfiles(data)
+	if err != nil {
+		return nil, err
+	}
+	return &ProfilesResult{
+		Profiles: availableProfiles(serviceProfiles),
+		Services: serviceProfiles,
+	}, nil
+}
+
+// ProjectInfo parses workDir's compose file and returns its shape:
+// service/network/volume counts, whether it builds images, and a rough
+// complexity rating.
+func (s *Service) ProjectInfo(workDir string) (*ProjectAnalysis, error) {
+	path, err := findComposeFile(workDir)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read compose file: %w", err)
+	}
+	analysis := analyzeCompose(data)
+	return &analysis, nil
+}
+
+// validateProfiles confirms every name in requested is declared by some
+// service in workDir's compose file, returning an error listing the
+// available profiles if not. An empty requested is always valid.
+func (s *Service) validateProfiles(workDir string, requested []string) error {
+	if len(requested) == 0 {
+		return nil
+	}
+	result, err := s.Profiles(workDir)
+	if err != nil {
+		return err
+	}
+	available := make(map[string]bool, len(result.Profiles))
+	for _, p := range result.Profiles {
+		available[p] = true
+	}
+	for _, p := range requested {
+		if !available[p] {
+			return fmt.Errorf("unknown profile %q; available profiles: %s", p, strings.Join(result.Profiles, ", "))
+		}
+	}
+	return nil
+}
+
+// profileArgs renders profiles as the repeatable --profile flags that
+// must precede the subcommand in a `docker compose` invocation.
+func profileArgs(profiles []string) []string {
+	args := make([]string, 0, len(profiles)*2)
+	for _, p := range profiles {
+		args = append(args, "--profile", p)
+	}
+	return args
+}
+
+func (c *Controller) handleProfiles(ctx context.Context, args json.RawMessage) (*mcp.CallToolResult, error) {
+	result, err := c.service.Profiles(c.dir())
+	if err != nil {
+		return nil, err
+	}
+	return marshalResult(true, result)
+}
+
+func (c *Controller) handleProjectInfo(ctx context.Context, args json.RawMessage) (*mcp.CallToolResult, error) {
+	result, err := c.service.ProjectInfo(c.dir())
+	if err != nil {
+		return nil, err
+	}
+	return marshalResult(true, result)
+}
@@ -0,0 +1,346 @@
+package compose
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// defaultComposeFiles lists the compose file names tried, in order, when
+// no explicit file is configured.
+var defaultComposeFiles = []string{"docker-compose.yml", "docker-compose.yaml", "compose.yml", "compose.yaml"}
+
+var (
+	serviceNameLine = regexp.MustCompile(`^  ([a-zA-Z0-9_.-]+):\s*$`)
+	dependsOnLine   = regexp.MustCompile(`^\s*depends_on:\s*$`)
+	dependsOnInline = regexp.MustCompile(`^\s*depends_on:\s*\[(.*)\]\s*$`)
+	listItemLine    = regexp.MustCompile(`^\s*-\s*"?([a-zA-Z0-9_.-]+)"?\s*$`)
+	mapItemLine     = regexp.MustCompile(`^\s*([a-zA-Z0-9_.-]+):\s*$`)
+	topLevelLine    = regexp.MustCompile(`^\S`)
+	profilesLine    = regexp.MustCompile(`^\s*profiles:\s*$`)
+	profilesInline  = regexp.MustCompile(`^\s*profiles:\s*\[(.*)\]\s*$`)
+	buildKeyLine    = regexp.MustCompile(`^\s{4,}build:`)
+)
+
+// findComposeFile locates the compose file for dir, trying the standard
+// names in order.
+func findComposeFile(dir string) (string, error) {
+	for _, name := range defaultComposeFiles {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("no compose file found in %s", dir)
+}
+
+// ProjectAnalysis summarizes a compose file's shape: how many services,
+// networks, and volumes it declares, whether any service builds an
+// image rather than only pulling one, and a rough complexity rating
+// derived from the service count.
+type ProjectAnalysis struct {
+	ServiceCount      int      `json:"serviceCount"`
+	Networks          []string `json:"networks"`
+	Volumes           []string `json:"volumes"`
+	UsesBuildContexts bool     `json:"usesBuildContexts"`
+	Complexity        string   `json:"complexity"`
+}
+
+// complexityForServiceCount buckets a service count into a rough
+// complexity rating, since the number of services is a better proxy for
+// how hard a stack is to reason about than the compose file's byte size.
+func complexityForServiceCount(count int) string {
+	switch {
+	case count <= 2:
+		return "simple"
+	case count <= 5:
+		return "moderate"
+	default:
+		return "complex"
+	}
+}
+
+// analyzeCompose does the same line-oriented scan as parseDependencyGraph
+// to count a compose file's services, top-level networks and volumes,
+// and detect whether any service declares a build context.
+func analyzeCompose(data []byte) ProjectAnalysis {
+	lines := strings.Split(string(data), "\n")
+
+	var serviceCount int
+	var usesBuildContexts bool
+	networks := topLevelBlockKeys(lines, "networks:")
+	volumes := topLevelBlockKeys(lines, "volumes:")
+
+	inServices := false
+	for _, raw := range lines {
+		line := strings.TrimRight(raw, "\r")
+
+		if strings.HasPrefix(line, "services:") {
+			inServices = true
+			continue
+		}
+		if !inServices {
+			continue
+		}
+		if topLevelLine.MatchString(line) && !strings.HasPrefix(line, "services:") {
+			inServices = false
+			continue
+		}
+
+		if serviceNameLine.MatchString(line) {
+			serviceCount++
+			continue
+		}
+		if buildKeyLine.MatchString(line) {
+			usesBuildContexts = true
+		}
+	}
+
+	return ProjectAnalysis{
+		ServiceCount:      serviceCount,
+		Networks:          networks,
+		Volumes:           volumes,
+		UsesBuildContexts: usesBuildContexts,
+		Complexity:        complexityForServiceCount(serviceCount),
+	}
+}
+
+// topLevelBlockKeys collects the names declared directly under a
+// top-level block (e.g. "networks:" or "volumes:"), the same way
+// serviceNameLine picks out service names under "services:".
+func topLevelBlockKeys(lines []string, blockHeader string) []string {
+	var keys []string
+	inBlock := false
+	for _, raw := range lines {
+		line := strings.TrimRight(raw, "\r")
+
+		if strings.HasPrefix(line, blockHeader) {
+			inBlock = true
+			continue
+		}
+		if !inBlock {
+			continue
+		}
+		if topLevelLine.MatchString(line) && !strings.HasPrefix(line, blockHeader) {
+			inBlock = false
+			continue
+		}
+		if m := serviceNameLine.FindStringSubmatch(line); m != nil {
+			keys = append(keys, m[1])
+		}
+	}
+	return keys
+}
+
+// parseDependencyGraph does a line-oriented, indentation-aware scan of a
+// compose file's top-level `services:` block, extracting each service's
+// `depends_on` list. It is intentionally lightweight rather than a full
+// YAML parser, since all we need here is service names and edges.
+func parseDependencyGraph(data []byte) (map[string][]string, error) {
+	lines := strings.Split(string(data), "\n")
+	graph := make(map[string][]string)
+
+	inServices := false
+	currentService := ""
+	inDependsOn := false
+
+	for _, raw := range lines {
+		line := strings.TrimRight(raw, "\r")
+
+		if strings.HasPrefix(line, "services:") {
+			inServices = true
+			currentService = ""
+			inDependsOn = false
+			continue
+		}
+		if !inServices {
+			continue
+		}
+		// A new top-level key ends the services block.
+		if topLevelLine.MatchString(line) && !strings.HasPrefix(line, "services:") {
+			inServices = false
+			continue
+		}
+
+		if m := serviceNameLine.FindStringSubmatch(line); m != nil {
+			currentService = m[1]
+			graph[currentService] = graph[currentService]
+			inDependsOn = false
+			continue
+		}
+		if currentService == "" {
+			continue
+		}
+
+		if m := dependsOnInline.FindStringSubmatch(line); m != nil {
+			for _, dep := range strings.Split(m[1], ",") {
+				dep = strings.Trim(strings.TrimSpace(dep), `"'`)
+				if dep != "" {
+					graph[currentService] = append(graph[currentService], dep)
+				}
+			}
+			inDependsOn = false
+			continue
+		}
+		if dependsOnLine.MatchString(line) {
+			inDependsOn = true
+			continue
+		}
+		if inDependsOn {
+			if m := listItemLine.FindStringSubmatch(line); m != nil {
+				graph[currentService] = append(graph[currentService], m[1])
+				continue
+			}
+			if m := mapItemLine.FindStringSubmatch(line); m != nil {
+				graph[currentService] = append(graph[currentService], m[1])
+				continue
+			}
+			inDependsOn = false
+		}
+	}
+
+	return graph, nil
+}
+
+// parseProfiles does the same line-oriented scan as parseDependencyGraph,
+// extracting each service's `profiles:` list instead of its
+// `depends_on:` one.
+func parseProfiles(data []byte) (map[string][]string, error) {
+	lines := strings.Split(string(data), "\n")
+	profiles := make(map[string][]string)
+
+	inServices := false
+	currentService := ""
+	inProfiles := false
+
+	for _, raw := range lines {
+		line := strings.TrimRight(raw, "\r")
+
+		if strings.HasPrefix(line, "services:") {
+			inServices = true
+			currentService = ""
+			inProfiles = false
+			continue
+		}
+		if !inServices {
+			continue
+		}
+		if topLevelLine.MatchString(line) && !strings.HasPrefix(line, "services:") {
+			inServices = false
+			continue
+		}
+
+		if m := serviceNameLine.FindStringSubmatch(line); m != nil {
+			currentService = m[1]
+			profiles[currentService] = profiles[currentService]
+			inProfiles = false
+			continue
+		}
+		if currentService == "" {
+			continue
+		}
+
+		if m := profilesInline.FindStringSubmatch(line); m != nil {
+			for _, p := range strings.Split(m[1], ",") {
+				p = strings.Trim(strings.TrimSpace(p), `"'`)
+				if p != "" {
+					profiles[currentService] = append(profiles[currentService], p)
+				}
+			}
+			inProfiles = false
+			continue
+		}
+		if profilesLine.MatchString(line) {
+			inProfiles = true
+			continue
+		}
+		if inProfiles {
+			if m := listItemLine.FindStringSubmatch(line); m != nil {
+				profiles[currentService] = append(profiles[currentService], m[1])
+				continue
+			}
+			inProfiles = false
+		}
+	}
+
+	return profiles, nil
+}
+
+// availableProfiles returns the sorted, deduplicated set of profile
+// names referenced anywhere in serviceProfiles.
+func availableProfiles(serviceProfiles map[string][]string) []string {
+	seen := make(map[string]bool)
+	for _, profiles := range serviceProfiles {
+		for _, p := range profiles {
+			seen[p] = true
+		}
+	}
+	names := make([]string, 0, len(seen))
+	for p := range seen {
+		names = append(names, p)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// servicesInProfiles filters candidates down to the services that opt
+// into at least one of wanted according to serviceProfiles. A service
+// with no profiles of its own is only included by compose's own "no
+// profile" default, which doesn't apply once a profile filter is in
+// play, so it's excluded here just like `docker compose --profile`
+// would exclude it.
+func servicesInProfiles(serviceProfiles map[string][]string, candidates, wanted []string) []string {
+	want := make(map[string]bool, len(wanted))
+	for _, p := range wanted {
+		want[p] = true
+	}
+
+	var filtered []string
+	for _, svc := range candidates {
+		for _, p := range serviceProfiles[svc] {
+			if want[p] {
+				filtered = append(filtered, svc)
+				break
+			}
+		}
+	}
+	return filtered
+}
+
+// buildWaves groups services into ordered waves such that every
+// dependency of a service in wave N appears in an earlier wave. Returns
+// an error if the graph contains a cycle.
+func buildWaves(graph map[string][]string) ([][]string, error) {
+	remaining := make(map[string][]string, len(graph))
+	for svc, deps := range graph {
+		remaining[svc] = append([]string(nil), deps...)
+	}
+
+	var waves [][]string
+	for len(remaining) > 0 {
+		var wave []string
+		for svc, deps := range remaining {
+			ready := true
+			for _, dep := range deps {
+				if _, stillRemaining := remaining[dep]; stillRemaining {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				wave = append(wave, svc)
+			}
+		}
+		if len(wave) == 0 {
+			return nil, fmt.Errorf("circular dependency detected among services: %v", remaining)
+		}
+		for _, svc := range wave {
+			delete(remaining, svc)
+		}
+		waves = append(waves, wave)
+	}
+	return waves, nil
+}
@@ -0,0 +1,114 @@
+package compose
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// PsParams are the arguments to the compose_ps tool.
+type PsParams struct {
+	Services []string `json:"services,omitempty"`
+	// Format selects "json" for a structured ServiceStatus array; any
+	// other value (including empty) returns compose's filtered text
+	// output, which reads better for a human.
+	Format string `json:"format,omitempty"`
+}
+
+// ServiceStatus is one service's entry from `docker compose ps`, reduced
+// to the fields an assistant needs to reason about a stack's state.
+type ServiceStatus struct {
+	Name   string   `json:"name"`
+	State  string   `json:"state"`
+	Health string   `json:"health,omitempty"`
+	Ports  []string `json:"ports,omitempty"`
+	Image  string   `json:"image,omitempty"`
+}
+
+// psJSONKeepKeys are the `docker compose ps --format json` fields
+// psEntry actually reads; handlePs runs raw output through
+// filter.FilterJSONLines with this list before parsing, so fields this
+// repo doesn't use (Command, CreatedAt, Labels, Mounts, ...) never reach
+// the assistant.
+var psJSONKeepKeys = []string{"Service", "Image", "State", "Health", "Publishers"}
+
+// psEntry is one line of `docker compose ps --format json`, which
+// prints one JSON object per service rather than a single array.
+type psEntry struct {
+	Service    string `json:"Service"`
+	Image      string `json:"Image"`
+	State      string `json:"State"`
+	Health     string `json:"Health"`
+	Publishers []struct {
+		URL           string `json:"URL"`
+		TargetPort    int    `json:"TargetPort"`
+		PublishedPort int    `json:"PublishedPort"`
+		Protocol      string `json:"Protocol"`
+	} `json:"Publishers"`
+}
+
+func buildPsArgs(params json.RawMessage) ([]string, error) {
+	var p PsParams
+	if len(params) > 0 {
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, fmt.Errorf("invalid compose_ps params: %w", err)
+		}
+	}
+	args := []string{"ps"}
+	if p.Format == "json" {
+		args = append(args, "--format", "json")
+	}
+	args = append(args, p.Services...)
+	return args, nil
+}
+
+// ParsePsJSON parses the newline-delimited JSON objects produced by
+// `docker compose ps --format json` into a ServiceStatus per service.
+func ParsePsJSON(raw string) ([]ServiceStatus, error) {
+	var statuses []ServiceStatus
+	for _, line := range strings.Split(raw, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		var entry psEntry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			return nil, fmt.Errorf("parse compose ps line %q: %w", line, err)
+		}
+		statuses = append(statuses, ServiceStatus{
+			Name:   entry.Service,
+			State:  entry.State,
+			Health: entry.Health,
+			Ports:  formatPublishers(entry.Publishers),
+			Image:  entry.Image,
+		})
+	}
+	return statuses, nil
+}
+
+// formatPublishers renders a service's published ports the way `docker
+// compose ps`'s text output does, e.g. "0.0.0.0:8080->80/tcp", so the
+// json and text paths describe ports the same way.
+func formatPublishers(publishers []struct {
+	URL           string `json:"URL"`
+	TargetPort    int    `json:"TargetPort"`
+	PublishedPort int    `json:"PublishedPort"`
+	Protocol      string `json:"Protocol"`
+}) []string {
+	if len(publishers) == 0 {
+		return nil
+	}
+	ports := make([]string, 0, len(publishers))
+	for _, p := range publishers {
+		if p.PublishedPort == 0 {
+			ports = append(ports, fmt.Sprintf("%d/%s", p.TargetPort, p.Protocol))
+			continue
+		}
+		host := p.URL
+		if host == "" {
+			host = "0.0.0.0"
+		}
+		ports = append(ports, fmt.Sprintf("%s:%d->%d/%s", host, p.PublishedPort, p.TargetPort, p.Protocol))
+	}
+	return ports
+}
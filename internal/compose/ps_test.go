@@ -0,0 +1,65 @@
+package compose
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestParsePsJSON(t *testing.T) {
+	raw := `{"Service":"web","Image":"nginx:latest","State":"running","Health":"healthy","Publishers":[{"URL":"0.0.0.0","TargetPort":80,"PublishedPort":8080,"Protocol":"tcp"}]}
+{"Service":"worker","Image":"app:latest","State":"exited","Health":"","Publishers":[{"URL":"","TargetPort":9000,"PublishedPort":0,"Protocol":"tcp"}]}
+`
+	statuses, err := ParsePsJSON(raw)
+	if err != nil {
+		t.Fatalf("ParsePsJSON: %v", err)
+	}
+
+	want := []ServiceStatus{
+		{Name: "web", State: "running", Health: "healthy", Ports: []string{"0.0.0.0:8080->80/tcp"}, Image: "nginx:latest"},
+		{Name: "worker", State: "exited", Ports: []string{"9000/tcp"}, Image: "app:latest"},
+	}
+	if !reflect.DeepEqual(statuses, want) {
+		t.Fatalf("statuses = %+v, want %+v", statuses, want)
+	}
+}
+
+func TestParsePsJSONSkipsBlankLines(t *testing.T) {
+	raw := "\n{\"Service\":\"web\",\"State\":\"running\"}\n\n"
+	statuses, err := ParsePsJSON(raw)
+	if err != nil {
+		t.Fatalf("ParsePsJSON: %v", err)
+	}
+	if len(statuses) != 1 || statuses[0].Name != "web" {
+		t.Fatalf("statuses = %+v, want one entry named web", statuses)
+	}
+}
+
+func TestParsePsJSONInvalidLine(t *testing.T) {
+	if _, err := ParsePsJSON("not json"); err == nil {
+		t.Fatal("ParsePsJSON should error on a non-JSON line")
+	}
+}
+
+func TestBuildPsArgsJSONFormat(t *testing.T) {
+	params, _ := json.Marshal(PsParams{Services: []string{"web"}, Format: "json"})
+	args, err := buildPsArgs(params)
+	if err != nil {
+		t.Fatalf("buildPsArgs: %v", err)
+	}
+	want := []string{"ps", "--format", "json", "web"}
+	if !reflect.DeepEqual(args, want) {
+		t.Fatalf("args = %#v, want %#v", args, want)
+	}
+}
+
+func TestBuildPsArgsDefaultFormat(t *testing.T) {
+	args, err := buildPsArgs(nil)
+	if err != nil {
+		t.Fatalf("buildPsArgs: %v", err)
+	}
+	want := []string{"ps"}
+	if !reflect.DeepEqual(args, want) {
+		t.Fatalf("args = %#v, want %#v", args, want)
+	}
+}
@@ -0,0 +1,27 @@
+package compose
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// PullParams are the arguments to the compose_pull tool.
+type PullParams struct {
+	Services []string `json:"services,omitempty"`
+	Quiet    bool     `json:"quiet,omitempty"`
+}
+
+func buildPullArgs(params json.RawMessage) ([]string, error) {
+	var p PullParams
+	if len(params) > 0 {
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, fmt.Errorf("invalid compose_pull params: %w", err)
+		}
+	}
+	args := []string{"pull"}
+	if p.Quiet {
+		args = append(args, "--quiet")
+	}
+	args = append(args, p.Services...)
+	return args, nil
+}
@@ -0,0 +1,109 @@
+package compose
+
+import (
+	"sync"
+	"time"
+)
+
+// readOnlyTools lists compose tools that don't mutate state and are
+// therefore safe to run concurrently even when queue mode is enabled.
+var readOnlyTools = map[string]bool{
+	"compose_ps":     true,
+	"compose_logs":   true,
+	"compose_top":    true,
+	"compose_images": true,
+	"compose_port":   true,
+}
+
+// QueuedOperation describes one operation tracked by the Queue.
+type QueuedOperation struct {
+	ID       int64     `json:"id"`
+	Tool     string    `json:"tool"`
+	Status   string    `json:"status"` // "pending" or "running"
+	QueuedAt time.Time `json:"queuedAt"`
+}
+
+// Queue serializes mutating compose operations onto a single worker so
+// hosts that can't handle concurrent compose invocations aren't
+// overwhelmed. Read-only operations bypass the queue entirely.
+type Queue struct {
+	mu      sync.Mutex
+	enabled bool
+	nextID  int64
+	pending []QueuedOperation
+	running *QueuedOperation
+	lock    sync.Mutex // serializes actual execution
+}
+
+// NewQueue creates a Queue. When enabled is false, Run executes
+// immediately without serialization bookkeeping.
+func NewQueue(enabled bool) *Queue {
+	return &Queue{enabled: enabled}
+}
+
+// IsReadOnly reports whether tool is safe to run outside the queue.
+func IsReadOnly(tool string) bool {
+	return readOnlyTools[tool]
+}
+
+// Run executes fn, serializing it against other mutating operations when
+// the queue is enabled and tool is not read-only.
+func (q *Queue) Run(tool string, fn func() (*CommandResult, error)) (*CommandResult, error) {
+	if !q.enabled || IsReadOnly(tool) {
+		return fn()
+	}
+
+	op := q.enqueue(tool)
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	q.start(op.ID)
+	defer q.finish(op.ID)
+
+	return fn()
+}
+
+func (q *Queue) enqueue(tool string) QueuedOperation {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.nextID++
+	op := QueuedOperation{ID: q.nextID, Tool: tool, Status: "pending", QueuedAt: time.Now()}
+	q.pending = append(q.pending, op)
+	return op
+}
+
+func (q *Queue) start(id int64) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for i, op := range q.pending {
+		if op.ID == id {
+			op.Status = "running"
+			q.running = &op
+			q.pending = append(q.pending[:i], q.pending[i+1:]...)
+			return
+		}
+	}
+}
+
+func (q *Queue) finish(id int64) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.running != nil && q.running.ID == id {
+		q.running = nil
+	}
+}
+
+// Status returns a snapshot of pending and running operations.
+func (q *Queue) Status() (pending []QueuedOperation, running *QueuedOperation) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	pending = append([]QueuedOperation(nil), q.pending...)
+	if q.running != nil {
+		r := *q.running
+		running = &r
+	}
+	return pending, running
+}
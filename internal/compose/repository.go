@@ -0,0 +1,159 @@
+package compose
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// Repository executes Docker Compose commands against the host. It is the
+// only layer in the compose package that shells out.
+type Repository interface {
+	Run(ctx context.Context, args []string) (stdout string, stderr string, exitCode int, err error)
+	// Preview assembles the full `docker ...` command line and working
+	// directory Run would use for args, without spawning anything.
+	Preview(args []string) (command string, dir string)
+}
+
+// execRepository runs `docker compose` via os/exec.
+type execRepository struct {
+	dirFunc         func() string
+	contextFunc     func() string
+	composeFileFunc func() string
+	envFunc         func() map[string]string
+	composeFiles    []string
+}
+
+// NewRepository creates a Repository that runs docker compose commands in
+// whatever directory dirFunc currently returns, so that changing the
+// active workspace takes effect on the very next command. If
+// contextFunc is non-nil and returns a non-empty name, every invocation
+// targets that Docker context via `docker --context`; pass nil to
+// always use the CLI's own default context. If composeFileFunc is
+// non-nil and returns a non-empty path, it's passed as `-f`, overriding
+// the COMPOSE_FILE environment variable for that invocation; otherwise,
+// if COMPOSE_FILE is set, its entries are passed as `-f` flags, in
+// order, matching Docker Compose's own handling of multiple compose
+// files. If envFunc is non-nil, the variables it returns are added to
+// the invocation's environment on top of the process's own.
+func NewRepository(dirFunc func() string, contextFunc func() string, composeFileFunc func() string, envFunc func() map[string]string) Repository {
+	return &execRepository{
+		dirFunc:         dirFunc,
+		contextFunc:     contextFunc,
+		composeFileFunc: composeFileFunc,
+		envFunc:         envFunc,
+		composeFiles:    composeFilesFromEnv(),
+	}
+}
+
+// composeFileSeparator matches Docker Compose's own COMPOSE_PATH_SEPARATOR
+// default: ':' on POSIX, ';' on Windows.
+func composeFileSeparator() string {
+	if sep := os.Getenv("COMPOSE_PATH_SEPARATOR"); sep != "" {
+		return sep
+	}
+	if runtime.GOOS == "windows" {
+		return ";"
+	}
+	return ":"
+}
+
+func composeFilesFromEnv() []string {
+	raw := os.Getenv("COMPOSE_FILE")
+	if raw == "" {
+		return nil
+	}
+	parts := strings.Split(raw, composeFileSeparator())
+	files := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			files = append(files, p)
+		}
+	}
+	return files
+}
+
+// fullArgs assembles the docker CLI arguments for args, adding the
+// --context flag, the "compose" subcommand, and any -f flags from
+// COMPOSE_FILE, in the order Run passes them to exec.
+func (r *execRepository) fullArgs(args []string) []string {
+	fullArgs := make([]string, 0, len(r.composeFiles)*2+len(args)+3)
+	if r.contextFunc != nil {
+		if dockerCtx := r.contextFunc(); dockerCtx != "" {
+			fullArgs = append(fullArgs, "--context", dockerCtx)
+		}
+	}
+	fullArgs = append(fullArgs, "compose")
+	if composeFile := r.workspaceComposeFile(); composeFile != "" {
+		fullArgs = append(fullArgs, "-f", composeFile)
+	} else {
+		for _, f := range r.composeFiles {
+			fullArgs = append(fullArgs, "-f", f)
+		}
+	}
+	fullArgs = append(fullArgs, args...)
+	return fullArgs
+}
+
+// workspaceComposeFile returns the active workspace's compose file
+// override, or "" if composeFileFunc is unset or returns none.
+func (r *execRepository) workspaceComposeFile() string {
+	if r.composeFileFunc == nil {
+		return ""
+	}
+	return r.composeFileFunc()
+}
+
+// extraEnv returns the active workspace's extra environment variables as
+// "KEY=value" entries, appended to os.Environ() so a command's
+// environment always includes them on top of the process's own.
+func (r *execRepository) extraEnv() []string {
+	if r.envFunc == nil {
+		return nil
+	}
+	vars := r.envFunc()
+	if len(vars) == 0 {
+		return nil
+	}
+	env := os.Environ()
+	for k, v := range vars {
+		env = append(env, k+"="+v)
+	}
+	return env
+}
+
+// Preview returns the command Run would execute for args, joined into a
+// single shell-quoted-ish string for display, and the directory it would
+// run in.
+func (r *execRepository) Preview(args []string) (string, string) {
+	fullArgs := r.fullArgs(args)
+	return "docker " + strings.Join(fullArgs, " "), r.dirFunc()
+}
+
+func (r *execRepository) Run(ctx context.Context, args []string) (string, string, int, error) {
+	fullArgs := r.fullArgs(args)
+
+	cmd := exec.CommandContext(ctx, "docker", fullArgs...)
+	cmd.Dir = r.dirFunc()
+	cmd.Env = r.extraEnv()
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	if err != nil {
+		exitCode := -1
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			exitCode = exitErr.ExitCode()
+		}
+		return stdout.String(), stderr.String(), exitCode, fmt.Errorf("docker compose %v: %w", args, err)
+	}
+	return stdout.String(), stderr.String(), 0, nil
+}
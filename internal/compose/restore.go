@@ -0,0 +1,39 @@
+package compose
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/jontolof/docker-compose-mcp/internal/mcp"
+)
+
+// RestoreParams are the arguments to the compose_volume_restore tool.
+type RestoreParams struct {
+	Volume   string `json:"volume"`
+	Snapshot string `json:"snapshot"`
+}
+
+func (c *Controller) handleVolumeRestore(ctx context.Context, args json.RawMessage) (*mcp.CallToolResult, error) {
+	var p RestoreParams
+	if err := json.Unmarshal(args, &p); err != nil {
+		return nil, fmt.Errorf("invalid compose_volume_restore params: %w", err)
+	}
+	if p.Volume == "" || p.Snapshot == "" {
+		return nil, fmt.Errorf("volume and snapshot are required")
+	}
+
+	snapshotPath := p.Snapshot
+	if !filepath.IsAbs(snapshotPath) {
+		snapshotPath = filepath.Join(c.dir(), snapshotDirName, snapshotPath)
+	}
+	if c.cfg != nil && c.cfg.IsPathRestricted(snapshotPath) {
+		return mcp.ErrorResult(fmt.Sprintf("path %q is restricted", snapshotPath)), nil
+	}
+
+	if err := restoreVolume(ctx, p.Volume, snapshotPath); err != nil {
+		return mcp.ErrorResult(err.Error()), nil
+	}
+	return mcp.TextResult(fmt.Sprintf("restored volume %q from %s", p.Volume, snapshotPath)), nil
+}
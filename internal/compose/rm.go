@@ -0,0 +1,57 @@
+package compose
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jontolof/docker-compose-mcp/internal/mcp"
+)
+
+// RmParams are the arguments to the compose_rm tool.
+type RmParams struct {
+	Services []string `json:"services,omitempty"`
+	// Force must be true for the command to actually run; compose rm is
+	// interactive by default and this server never attaches a terminal
+	// to answer its prompt.
+	Force bool `json:"force,omitempty"`
+	// Stop stops the services first (-s) instead of failing if they're
+	// still running.
+	Stop bool `json:"stop,omitempty"`
+	// Volumes removes the services' anonymous volumes along with their
+	// containers (-v). Named volumes are never touched by compose rm.
+	Volumes bool `json:"volumes,omitempty"`
+}
+
+func buildRmArgs(params json.RawMessage) ([]string, error) {
+	var p RmParams
+	if len(params) > 0 {
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, fmt.Errorf("invalid compose_rm params: %w", err)
+		}
+	}
+	args := []string{"rm", "-f"}
+	if p.Stop {
+		args = append(args, "-s")
+	}
+	if p.Volumes {
+		args = append(args, "-v")
+	}
+	args = append(args, p.Services...)
+	return args, nil
+}
+
+func (c *Controller) handleRm(ctx context.Context, args json.RawMessage) (*mcp.CallToolResult, error) {
+	var p RmParams
+	if len(args) > 0 {
+		if err := json.Unmarshal(args, &p); err != nil {
+			return nil, fmt.Errorf("invalid compose_rm params: %w", err)
+		}
+	}
+	if !p.Force {
+		return mcp.ErrorResult("compose_rm requires force=true, since compose rm otherwise prompts interactively to confirm"), nil
+	}
+
+	result, err := c.service.Execute(ctx, "compose_rm", args)
+	return toolResult(result, err)
+}
@@ -0,0 +1,48 @@
+package compose
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// RunParams are the arguments to the compose_run tool. Command is a
+// shell-style command line; Args, if given, are passed through verbatim
+// instead of being parsed from Command.
+type RunParams struct {
+	Service string   `json:"service"`
+	Command string   `json:"command,omitempty"`
+	Args    []string `json:"args,omitempty"`
+	Rm      bool     `json:"rm,omitempty"`
+	NoDeps  bool     `json:"noDeps,omitempty"`
+}
+
+func buildRunArgs(params json.RawMessage) ([]string, error) {
+	var p RunParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, fmt.Errorf("invalid compose_run params: %w", err)
+	}
+	if p.Service == "" {
+		return nil, fmt.Errorf("service is required")
+	}
+
+	args := []string{"run"}
+	if p.Rm {
+		args = append(args, "--rm")
+	}
+	if p.NoDeps {
+		args = append(args, "--no-deps")
+	}
+	args = append(args, p.Service)
+
+	switch {
+	case len(p.Args) > 0:
+		args = append(args, p.Args...)
+	case p.Command != "":
+		parsed, err := splitCommandLine(p.Command)
+		if err != nil {
+			return nil, fmt.Errorf("parse command: %w", err)
+		}
+		args = append(args, parsed...)
+	}
+	return args, nil
+}
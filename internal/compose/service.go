@@ -0,0 +1,493 @@
+package compose
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/jontolof/docker-compose-mcp/internal/config"
+	"github.com/jontolof/docker-compose-mcp/internal/filter"
+	"github.com/jontolof/docker-compose-mcp/internal/plugin"
+)
+
+// Builder turns a tool's JSON params into the `docker compose` CLI
+// arguments that implement it. Each tool registers its own builder so
+// that commands can be replayed without the replay logic knowing the
+// shape of every tool's params.
+type Builder func(params json.RawMessage) ([]string, error)
+
+// Service contains the business logic shared by all compose tools:
+// building commands, executing them, filtering their output, and
+// recording history for replay.
+type Service struct {
+	repo        Repository
+	filter      *filter.Filter
+	history     *History
+	builders    map[string]Builder
+	queue       *Queue
+	concurrency *ConcurrencyLimiter
+	configCache *ConfigCache
+	metrics     *filter.FilterMetrics
+	events      *plugin.Manager
+	cfg         *config.Config
+
+	inflight  sync.WaitGroup
+	cancelMu  sync.Mutex
+	cancels   map[int64]context.CancelFunc
+	nextCmdID int64
+}
+
+// NewService creates a Service backed by repo. Mutating operations run
+// concurrently; use EnableQueue to serialize them instead. configCacheStatePath
+// is forwarded to NewConfigCache; pass an empty string to keep the cache
+// in memory only.
+func NewService(repo Repository, configCacheStatePath string) *Service {
+	return &Service{
+		repo:        repo,
+		filter:      filter.New(),
+		history:     NewHistory(0),
+		builders:    make(map[string]Builder),
+		queue:       NewQueue(false),
+		concurrency: NewConcurrencyLimiter(defaultConcurrencyLimit),
+		configCache: NewConfigCache(configCacheStatePath),
+		metrics:     filter.NewFilterMetrics(),
+		cancels:     make(map[int64]context.CancelFunc),
+	}
+}
+
+// EnableQueue turns on serialized execution for mutating compose
+// commands; read-only commands continue to run concurrently.
+func (s *Service) EnableQueue(enabled bool) {
+	s.queue = NewQueue(enabled)
+}
+
+// Queue exposes the service's operation queue, primarily so tools outside
+// the compose package can report its status.
+func (s *Service) Queue() *Queue {
+	return s.queue
+}
+
+// Concurrency exposes the service's subprocess concurrency limiter,
+// primarily so tools outside the compose package can report its status.
+func (s *Service) Concurrency() *ConcurrencyLimiter {
+	return s.concurrency
+}
+
+// RegisterBuilder associates a tool name with the Builder that turns its
+// params into compose CLI arguments.
+func (s *Service) RegisterBuilder(tool string, builder Builder) {
+	s.builders[tool] = builder
+}
+
+// History exposes the service's command history.
+func (s *Service) History() *History {
+	return s.history
+}
+
+// Filter exposes the service's output filter, primarily so tools outside
+// the compose package can inspect its configuration.
+func (s *Service) Filter() *filter.Filter {
+	return s.filter
+}
+
+// ConfigCache exposes the service's compose-file service-name cache.
+func (s *Service) ConfigCache() *ConfigCache {
+	return s.configCache
+}
+
+// Metrics exposes the service's per-tool filtering and latency metrics.
+func (s *Service) Metrics() *filter.FilterMetrics {
+	return s.metrics
+}
+
+// SetEventManager wires a plugin manager into the service so compose
+// commands fire events plugins can react to. Pass nil (the default) to
+// run without plugin events.
+func (s *Service) SetEventManager(m *plugin.Manager) {
+	s.events = m
+}
+
+// SetConfig wires the server config into the service so Execute can
+// reject base compose commands that aren't in cfg.AllowedCommands, and
+// sizes the subprocess concurrency limiter from cfg.MaxWorkers. Pass nil
+// (the default) to leave every command allowed and the limiter at
+// defaultConcurrencyLimit.
+func (s *Service) SetConfig(cfg *config.Config) {
+	s.cfg = cfg
+	if cfg != nil {
+		s.concurrency = NewConcurrencyLimiter(cfg.MaxWorkers)
+	}
+}
+
+// fireEvent delivers evt to the plugin manager, if one is set.
+func (s *Service) fireEvent(evt plugin.Event) {
+	if s.events == nil {
+		return
+	}
+	s.events.FireEvent(evt)
+}
+
+// Execute builds and runs the named tool's command, filters its output,
+// and records the attempt in history.
+func (s *Service) Execute(ctx context.Context, tool string, params json.RawMessage) (*CommandResult, error) {
+	builder, ok := s.builders[tool]
+	if !ok {
+		return nil, fmt.Errorf("no command builder registered for tool %q", tool)
+	}
+
+	args, err := builder(params)
+	if err != nil {
+		return nil, fmt.Errorf("build args for %s: %w", tool, err)
+	}
+
+	if s.cfg != nil && len(args) > 0 && !s.cfg.IsCommandAllowed(args[0]) {
+		return nil, fmt.Errorf("command %q is not allowed", args[0])
+	}
+
+	if envFiles := extractEnvFiles(params); len(envFiles) > 0 {
+		if err := s.validateEnvFiles(envFiles); err != nil {
+			return nil, err
+		}
+		envArgs := make([]string, 0, len(envFiles)*2)
+		for _, f := range envFiles {
+			envArgs = append(envArgs, "--env-file", f)
+		}
+		args = append(envArgs, args...)
+	}
+
+	projectName := extractProjectName(params)
+	if projectName == "" && s.cfg != nil {
+		projectName = s.cfg.ProjectName
+	}
+	if projectName != "" {
+		args = append([]string{"-p", projectName}, args...)
+	}
+
+	dryRun := extractDryRun(params)
+	if s.cfg != nil && s.cfg.DryRunDefault {
+		dryRun = true
+	}
+	if dryRun {
+		command, dir := s.repo.Preview(args)
+		return &CommandResult{Tool: tool, Args: args, Success: true, DryRun: true, Command: command, Dir: dir}, nil
+	}
+
+	services := extractServiceNames(params)
+	s.fireEvent(plugin.Event{Type: plugin.EventPreCommand, Data: map[string]interface{}{"tool": tool, "services": services}})
+
+	// A detached command (e.g. compose_exec with detach=true) returns
+	// almost as soon as docker hands back the exec ID, so it shouldn't be
+	// bound by the long default command timeout meant for commands whose
+	// output we wait on.
+	if !extractDetach(params) {
+		timeout := s.commandTimeout()
+		if override, ok := extractTimeout(params); ok {
+			if max := s.maxCommandTimeout(); max > 0 && override > max {
+				override = max
+			}
+			timeout = override
+		}
+		if timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+	}
+
+	ctx, release := s.trackInflight(ctx)
+	defer release()
+
+	if err := s.concurrency.Acquire(ctx); err != nil {
+		return nil, fmt.Errorf("waiting for a free compose worker: %w", err)
+	}
+	defer s.concurrency.Release()
+
+	result, runErr := s.queue.Run(tool, func() (*CommandResult, error) {
+		start := time.Now()
+		stdout, stderr, exitCode, runErr := s.runWithRetry(ctx, tool, args)
+		latency := time.Since(start)
+		success := runErr == nil
+
+		s.history.Record(HistoryEntry{
+			Tool:      tool,
+			Args:      args,
+			Params:    params,
+			Timestamp: time.Now(),
+			ExitCode:  exitCode,
+			Success:   success,
+		})
+
+		raw := stdout + stderr
+		output := s.filter.Apply(raw, filterKindForTool(tool))
+		s.metrics.Record(tool, len(raw), len(output), latency)
+
+		result := &CommandResult{
+			Tool:     tool,
+			Args:     args,
+			Output:   output,
+			ExitCode: exitCode,
+			Success:  success,
+		}
+		if !success {
+			result.ErrorKind = classifyError(stderr)
+		}
+		return result, runErr
+	})
+
+	if runErr != nil {
+		s.fireEvent(plugin.Event{Type: plugin.EventError, Data: map[string]interface{}{"tool": tool, "services": services, "error": runErr.Error()}})
+	} else {
+		switch tool {
+		case "compose_up":
+			s.fireEvent(plugin.Event{Type: plugin.EventServiceStart, Data: map[string]interface{}{"tool": tool, "services": services}})
+		case "compose_down":
+			s.fireEvent(plugin.Event{Type: plugin.EventServiceStop, Data: map[string]interface{}{"tool": tool, "services": services}})
+		}
+	}
+	s.fireEvent(plugin.Event{Type: plugin.EventPostCommand, Data: map[string]interface{}{"tool": tool, "services": services}})
+
+	return result, runErr
+}
+
+// trackInflight registers ctx as an in-flight command so Drain knows to
+// wait for it (or cancel it) on shutdown. The returned context carries
+// its own cancellation independent of any per-call timeout already
+// applied; release must be called exactly once when the command
+// finishes.
+func (s *Service) trackInflight(ctx context.Context) (tracked context.Context, release func()) {
+	tracked, cancel := context.WithCancel(ctx)
+	s.inflight.Add(1)
+
+	s.cancelMu.Lock()
+	id := s.nextCmdID
+	s.nextCmdID++
+	s.cancels[id] = cancel
+	s.cancelMu.Unlock()
+
+	return tracked, func() {
+		cancel()
+		s.cancelMu.Lock()
+		delete(s.cancels, id)
+		s.cancelMu.Unlock()
+		s.inflight.Done()
+	}
+}
+
+// DrainResult reports how a Drain ended: commands that finished on
+// their own versus ones still running when the deadline hit and had to
+// be cancelled.
+type DrainResult struct {
+	Drained   int `json:"drained"`
+	Cancelled int `json:"cancelled"`
+}
+
+// Drain waits for in-flight compose commands to finish until ctx is
+// done, then cancels any still running. Call this during shutdown so a
+// long build or pull isn't killed mid-write without a chance to finish.
+func (s *Service) Drain(ctx context.Context) DrainResult {
+	s.cancelMu.Lock()
+	pending := len(s.cancels)
+	s.cancelMu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.inflight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return DrainResult{Drained: pending}
+	case <-ctx.Done():
+	}
+
+	s.cancelMu.Lock()
+	cancelled := len(s.cancels)
+	for _, cancel := range s.cancels {
+		cancel()
+	}
+	s.cancelMu.Unlock()
+
+	<-done
+	return DrainResult{Drained: pending - cancelled, Cancelled: cancelled}
+}
+
+// defaultCommandTimeout and defaultMaxCommandTimeout apply when no
+// config is set, e.g. in tests or callers that construct Service
+// directly without Load()ing one.
+const (
+	defaultCommandTimeout    = 5 * time.Minute
+	defaultMaxCommandTimeout = 15 * time.Minute
+)
+
+// commandTimeout returns the configured default timeout for a single
+// compose invocation.
+func (s *Service) commandTimeout() time.Duration {
+	if s.cfg == nil || s.cfg.CommandTimeout <= 0 {
+		return defaultCommandTimeout
+	}
+	return time.Duration(s.cfg.CommandTimeout) * time.Second
+}
+
+// maxCommandTimeout returns the ceiling a per-call timeout override may
+// not exceed.
+func (s *Service) maxCommandTimeout() time.Duration {
+	if s.cfg == nil || s.cfg.MaxCommandTimeout <= 0 {
+		return defaultMaxCommandTimeout
+	}
+	return time.Duration(s.cfg.MaxCommandTimeout) * time.Second
+}
+
+// transientDockerErrorPattern matches Docker CLI errors that indicate
+// the daemon wasn't reachable yet rather than a real command failure -
+// the socket not being up during a restart, a dropped connection - so
+// runWithRetry knows when retrying is worth it.
+var transientDockerErrorPattern = regexp.MustCompile(`(?i)cannot connect to the docker daemon|connection refused|i/o timeout|unexpected eof`)
+
+// isTransientDockerError reports whether err looks like a transient
+// failure reaching the Docker daemon.
+func isTransientDockerError(err error) bool {
+	return err != nil && transientDockerErrorPattern.MatchString(err.Error())
+}
+
+// runWithRetry runs args through repo.Run, retrying with a linear
+// backoff when tool is read-only (see readOnlyTools) and the failure
+// looks transient. Mutating commands like up and down are never
+// retried, since re-running one that partially applied could make
+// things worse rather than better.
+func (s *Service) runWithRetry(ctx context.Context, tool string, args []string) (stdout, stderr string, exitCode int, err error) {
+	attempts := 1
+	if readOnlyTools[tool] {
+		attempts += s.retryCount()
+	}
+	delay := s.retryDelay()
+
+	for attempt := 1; ; attempt++ {
+		stdout, stderr, exitCode, err = s.repo.Run(ctx, args)
+		if err == nil || attempt >= attempts || !isTransientDockerError(err) {
+			return stdout, stderr, exitCode, err
+		}
+		select {
+		case <-ctx.Done():
+			return stdout, stderr, exitCode, err
+		case <-time.After(delay * time.Duration(attempt)):
+		}
+	}
+}
+
+// defaultRetryCount and defaultRetryDelay apply when no config is set.
+const (
+	defaultRetryCount = 2
+	defaultRetryDelay = 1 * time.Second
+)
+
+// retryCount returns how many extra attempts a read-only command gets
+// after a transient Docker daemon error.
+func (s *Service) retryCount() int {
+	if s.cfg == nil || s.cfg.RetryCount < 0 {
+		return defaultRetryCount
+	}
+	return s.cfg.RetryCount
+}
+
+// retryDelay returns the base backoff between retry attempts.
+func (s *Service) retryDelay() time.Duration {
+	if s.cfg == nil || s.cfg.RetryDelay <= 0 {
+		return defaultRetryDelay
+	}
+	return time.Duration(s.cfg.RetryDelay) * time.Second
+}
+
+// validateEnvFiles confirms each env file exists and isn't under a
+// restricted path, so --env-file can't be used to read arbitrary host
+// files through a compose tool call.
+func (s *Service) validateEnvFiles(files []string) error {
+	for _, f := range files {
+		if _, err := os.Stat(f); err != nil {
+			return fmt.Errorf("env_file %q: %w", f, err)
+		}
+		if s.cfg != nil && s.cfg.IsPathRestricted(f) {
+			return fmt.Errorf("env_file %q is restricted", f)
+		}
+	}
+	return nil
+}
+
+// filterKindForTool picks the output filter that best preserves a tool's
+// own output shape; tools without a more specific kind fall back to the
+// generic noise-stripping filter.
+func filterKindForTool(tool string) filter.Kind {
+	switch tool {
+	case "compose_images":
+		return filter.KindImages
+	case "compose_port", "compose_db_backup", "compose_migrate":
+		return filter.KindRaw
+	default:
+		return filter.KindGeneral
+	}
+}
+
+// extractServiceNames pulls the "services" field out of a tool's raw
+// params, if present, so events can report which services a command
+// targeted without each builder needing to know about plugin events.
+func extractServiceNames(params json.RawMessage) []string {
+	var fields struct {
+		Services []string `json:"services"`
+	}
+	if len(params) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(params, &fields); err != nil {
+		return nil
+	}
+	return fields.Services
+}
+
+// Replay re-runs the most recent command in history, or the most recent
+// failed command if preferFailed is true, optionally merging overrides
+// into its original params first.
+func (s *Service) Replay(ctx context.Context, preferFailed bool, overrides map[string]json.RawMessage) (*CommandResult, error) {
+	var (
+		entry HistoryEntry
+		ok    bool
+	)
+	if preferFailed {
+		entry, ok = s.history.LastFailed()
+	}
+	if !ok {
+		entry, ok = s.history.Last()
+	}
+	if !ok {
+		return nil, fmt.Errorf("no command history to replay")
+	}
+
+	params := entry.Params
+	if len(overrides) > 0 {
+		merged, err := mergeParams(entry.Params, overrides)
+		if err != nil {
+			return nil, fmt.Errorf("merge replay overrides: %w", err)
+		}
+		params = merged
+	}
+
+	return s.Execute(ctx, entry.Tool, params)
+}
+
+// mergeParams shallow-merges overrides into the fields of base, which
+// must be a JSON object.
+func mergeParams(base json.RawMessage, overrides map[string]json.RawMessage) (json.RawMessage, error) {
+	fields := make(map[string]json.RawMessage)
+	if len(base) > 0 {
+		if err := json.Unmarshal(base, &fields); err != nil {
+			return nil, fmt.Errorf("base params are not a JSON object: %w", err)
+		}
+	}
+	for k, v := range overrides {
+		fields[k] = v
+	}
+	return json.Marshal(fields)
+}
@@ -0,0 +1,109 @@
+package compose
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jontolof/docker-compose-mcp/internal/mcp"
+)
+
+// SessionReadParams are the arguments to the compose_session_read tool.
+type SessionReadParams struct {
+	SessionID string `json:"sessionId"`
+	Cursor    int    `json:"cursor,omitempty"`
+}
+
+// SessionReadResult is the result of compose_session_read: a chunk of
+// newly produced output and the cursor to pass on the next call.
+type SessionReadResult struct {
+	Output string `json:"output"`
+	Cursor int    `json:"cursor"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+func (c *Controller) handleSessionRead(ctx context.Context, args json.RawMessage) (*mcp.CallToolResult, error) {
+	var p SessionReadParams
+	if err := json.Unmarshal(args, &p); err != nil {
+		return nil, fmt.Errorf("invalid compose_session_read params: %w", err)
+	}
+
+	sess, ok := c.sessions.Get(p.SessionID)
+	if !ok {
+		return nil, fmt.Errorf("unknown session %q", p.SessionID)
+	}
+
+	chunk, cursor := sess.ReadFrom(p.Cursor)
+	status, sessErr := sess.State()
+
+	result := SessionReadResult{Output: chunk, Cursor: cursor, Status: string(status)}
+	if sessErr != nil {
+		result.Error = sessErr.Error()
+	}
+	payload, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	return mcp.TextResult(string(payload)), nil
+}
+
+// SessionInfo summarizes one active or recently finished background
+// session for listing.
+type SessionInfo struct {
+	ID        string `json:"id"`
+	Tool      string `json:"tool"`
+	Status    string `json:"status"`
+	StartedAt string `json:"startedAt"`
+}
+
+func (c *Controller) handleSessionList(ctx context.Context, args json.RawMessage) (*mcp.CallToolResult, error) {
+	sessions := c.sessions.List()
+	infos := make([]SessionInfo, 0, len(sessions))
+	for _, sess := range sessions {
+		status, _ := sess.State()
+		infos = append(infos, SessionInfo{
+			ID:        sess.ID,
+			Tool:      sess.Tool,
+			Status:    string(status),
+			StartedAt: sess.StartedAt.Format(time.RFC3339),
+		})
+	}
+
+	payload, err := json.Marshal(infos)
+	if err != nil {
+		return nil, err
+	}
+	return mcp.TextResult(string(payload)), nil
+}
+
+// SessionStopParams are the arguments to the compose_session_stop tool.
+type SessionStopParams struct {
+	SessionID string `json:"sessionId"`
+}
+
+func (c *Controller) handleSessionStop(ctx context.Context, args json.RawMessage) (*mcp.CallToolResult, error) {
+	var p SessionStopParams
+	if err := json.Unmarshal(args, &p); err != nil {
+		return nil, fmt.Errorf("invalid compose_session_stop params: %w", err)
+	}
+
+	sess, ok := c.sessions.Get(p.SessionID)
+	if !ok {
+		return nil, fmt.Errorf("unknown session %q", p.SessionID)
+	}
+	sess.Stop()
+	return mcp.TextResult(fmt.Sprintf("stopped session %q", p.SessionID)), nil
+}
+
+func (c *Controller) handleSessionMetrics(ctx context.Context, args json.RawMessage) (*mcp.CallToolResult, error) {
+	metrics := c.sessions.Metrics()
+	metrics.AverageLifetime = metrics.AverageLifetime.Round(time.Second)
+
+	payload, err := json.Marshal(metrics)
+	if err != nil {
+		return nil, err
+	}
+	return mcp.TextResult(string(payload)), nil
+}
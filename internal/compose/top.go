@@ -0,0 +1,23 @@
+package compose
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// TopParams are the arguments to the compose_top tool.
+type TopParams struct {
+	Services []string `json:"services,omitempty"`
+}
+
+func buildTopArgs(params json.RawMessage) ([]string, error) {
+	var p TopParams
+	if len(params) > 0 {
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, fmt.Errorf("invalid compose_top params: %w", err)
+		}
+	}
+	args := []string{"top"}
+	args = append(args, p.Services...)
+	return args, nil
+}
@@ -0,0 +1,288 @@
+package compose
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jontolof/docker-compose-mcp/internal/executor"
+)
+
+// FailedService describes why a single service failed to start.
+type FailedService struct {
+	Service string `json:"service"`
+	Reason  string `json:"reason"`
+}
+
+// UpResult is the structured result of compose_up, distinguishing
+// services that started successfully from ones that failed so an agent
+// can retry only what's broken.
+type UpResult struct {
+	Succeeded []string        `json:"succeeded"`
+	Failed    []FailedService `json:"failed"`
+	Output    string          `json:"output"`
+	// Healthy and TimedOut are only populated when the request set Wait:
+	// Healthy lists services that reported healthy before the deadline,
+	// TimedOut lists ones that didn't.
+	Healthy  []string `json:"healthy,omitempty"`
+	TimedOut []string `json:"timedOut,omitempty"`
+}
+
+// Up starts the requested services. When params.Parallel is set, it
+// computes dependency waves from the project's depends_on graph, same
+// as Build, and runs each wave's services concurrently through the
+// executor pool, up to params.MaxWorkers at a time, passing --no-deps
+// to each per-service invocation since the wave ordering already
+// guarantees a service's dependencies are running by the time its own
+// wave starts. This bypasses the history/queue machinery Execute
+// provides, matching Build's precedent for multi-invocation operations.
+func (s *Service) Up(ctx context.Context, workDir string, params UpParams) (*UpResult, error) {
+	services := params.Services
+	waves := [][]string{services}
+
+	if params.Parallel {
+		if path, err := findComposeFile(workDir); err == nil {
+			if data, readErr := os.ReadFile(path); readErr == nil {
+				if graph, parseErr := parseDependencyGraph(data); parseErr == nil {
+					if len(services) > 0 {
+						graph = filterGraph(graph, services)
+					}
+					if computed, waveErr := buildWaves(graph); waveErr == nil && len(computed) > 0 {
+						waves = computed
+					}
+				}
+			}
+		}
+	}
+
+	workers := params.MaxWorkers
+	if workers <= 0 {
+		workers = 4
+	}
+
+	result := &UpResult{}
+	var combinedOutput strings.Builder
+	var lastErr error
+
+	for _, wave := range waves {
+		if len(wave) == 0 {
+			continue
+		}
+		if !params.Parallel || len(wave) == 1 {
+			for _, svc := range wave {
+				output, err := s.upOne(ctx, svc)
+				combinedOutput.WriteString(output)
+				recordUpOutcome(result, svc, output, err)
+				if err != nil {
+					lastErr = err
+				}
+			}
+			continue
+		}
+
+		exec := executor.New(workers)
+		jobs := make([]executor.Job, 0, len(wave))
+		for _, svc := range wave {
+			svc := svc
+			jobs = append(jobs, executor.Job{
+				ID: svc,
+				Fn: func(ctx context.Context) (interface{}, error) {
+					return s.upOne(ctx, svc)
+				},
+			})
+		}
+		for _, r := range exec.RunAll(jobs) {
+			output, _ := r.Value.(string)
+			combinedOutput.WriteString(output)
+			recordUpOutcome(result, r.ID, output, r.Err)
+			if r.Err != nil {
+				lastErr = r.Err
+			}
+		}
+		exec.Shutdown()
+	}
+
+	result.Output = combinedOutput.String()
+	return result, lastErr
+}
+
+// upOne starts a single service, without pulling in its dependencies
+// again, and returns its combined output.
+func (s *Service) upOne(ctx context.Context, service string) (string, error) {
+	stdout, stderr, _, err := s.repo.Run(ctx, []string{"up", "-d", "--no-deps", service})
+	return stdout + stderr, err
+}
+
+// recordUpOutcome appends service to result.Succeeded or result.Failed
+// based on err, using output as the failure reason when err carries no
+// more specific message.
+func recordUpOutcome(result *UpResult, service, output string, err error) {
+	if err != nil {
+		reason := strings.TrimSpace(output)
+		if reason == "" {
+			reason = err.Error()
+		}
+		result.Failed = append(result.Failed, FailedService{Service: service, Reason: reason})
+		return
+	}
+	result.Succeeded = append(result.Succeeded, service)
+}
+
+// serviceErrorPattern matches docker compose error lines that name the
+// failing service, e.g. "Error response from daemon: ... container web".
+var serviceErrorPattern = regexp.MustCompile(`(?i)(?:error|failed).*?\b(?:service|container)\s+"?([a-zA-Z0-9_.-]+)"?`)
+
+// classifyUpResult splits requested into succeeded/failed based on the
+// filtered output of a compose_up invocation. A service is considered
+// failed if an error line names it; every other requested service is
+// considered succeeded. If requested is empty, all services defined by
+// the project are assumed requested and no classification is attempted
+// beyond what the output names.
+func classifyUpResult(requested []string, output string, upErr error) *UpResult {
+	failedReasons := make(map[string]string)
+	for _, line := range strings.Split(output, "\n") {
+		m := serviceErrorPattern.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		failedReasons[m[1]] = strings.TrimSpace(line)
+	}
+
+	result := &UpResult{Output: output}
+	if len(requested) == 0 {
+		for svc, reason := range failedReasons {
+			result.Failed = append(result.Failed, FailedService{Service: svc, Reason: reason})
+		}
+		return result
+	}
+
+	for _, svc := range requested {
+		if reason, failed := failedReasons[svc]; failed {
+			result.Failed = append(result.Failed, FailedService{Service: svc, Reason: reason})
+			continue
+		}
+		result.Succeeded = append(result.Succeeded, svc)
+	}
+
+	// An overall failure with no service-specific match means we
+	// couldn't attribute blame; surface it against every requested
+	// service rather than silently reporting success.
+	if upErr != nil && len(failedReasons) == 0 {
+		result.Succeeded = nil
+		result.Failed = nil
+		for _, svc := range requested {
+			result.Failed = append(result.Failed, FailedService{Service: svc, Reason: upErr.Error()})
+		}
+	}
+
+	return result
+}
+
+// failedServiceNames extracts the service name from each FailedService,
+// for callers that only need the names already tracked by UpResult.
+func failedServiceNames(failed []FailedService) []string {
+	names := make([]string, 0, len(failed))
+	for _, f := range failed {
+		names = append(names, f.Service)
+	}
+	return names
+}
+
+// defaultWaitTimeout bounds how long waitForHealthy polls when a
+// compose_up request sets Wait but not WaitTimeout.
+const defaultWaitTimeout = 60 * time.Second
+
+// waitUnsupportedPattern matches the compose CLI's error for a --wait
+// flag it predates, so callers can fall back to polling instead.
+var waitUnsupportedPattern = regexp.MustCompile(`(?i)unknown flag:\s*--wait`)
+
+// needsWaitFallback reports whether output indicates the installed
+// compose CLI doesn't understand --wait, so the caller should retry the
+// plain `up -d` and poll compose ps itself instead.
+func needsWaitFallback(output string, err error) bool {
+	return err != nil && waitUnsupportedPattern.MatchString(output)
+}
+
+// waitTimeoutDuration turns a wait_timeout in seconds into a Duration,
+// substituting defaultWaitTimeout for zero or negative values.
+func waitTimeoutDuration(seconds int) time.Duration {
+	if seconds <= 0 {
+		return defaultWaitTimeout
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// serviceHealth is the subset of `docker compose ps --format json`
+// fields needed to tell whether a service's container has become
+// healthy.
+type serviceHealth struct {
+	Service string `json:"Service"`
+	State   string `json:"State"`
+	Health  string `json:"Health"`
+}
+
+// pollHealthInterval is how often waitForHealthy re-checks compose ps
+// while services are still pending.
+const pollHealthInterval = 2 * time.Second
+
+// waitForHealthy polls `docker compose ps` until every service in
+// services reports healthy, or timeout elapses. A service with no
+// healthcheck defined reports an empty Health, so it's considered
+// healthy as soon as it's running. This is the fallback path for
+// compose CLIs too old to understand `up --wait` natively.
+func (s *Service) waitForHealthy(ctx context.Context, services []string, timeout time.Duration) (healthy, timedOut []string) {
+	deadline := time.Now().Add(timeout)
+	pending := append([]string(nil), services...)
+
+	for {
+		statuses := s.queryServiceHealth(ctx)
+		var stillPending []string
+		for _, svc := range pending {
+			state, ok := statuses[svc]
+			if ok && strings.HasPrefix(strings.ToLower(state.State), "running") && (state.Health == "" || strings.EqualFold(state.Health, "healthy")) {
+				healthy = append(healthy, svc)
+				continue
+			}
+			stillPending = append(stillPending, svc)
+		}
+		pending = stillPending
+
+		if len(pending) == 0 || time.Now().After(deadline) {
+			return healthy, pending
+		}
+
+		select {
+		case <-ctx.Done():
+			return healthy, pending
+		case <-time.After(pollHealthInterval):
+		}
+	}
+}
+
+// queryServiceHealth runs `docker compose ps --format json` and indexes
+// the result by service name. Docker prints one JSON object per line
+// rather than a single array, so each line is decoded independently;
+// lines that aren't valid JSON are skipped.
+func (s *Service) queryServiceHealth(ctx context.Context) map[string]serviceHealth {
+	stdout, _, _, err := s.repo.Run(ctx, []string{"ps", "--format", "json"})
+	if err != nil {
+		return nil
+	}
+
+	statuses := make(map[string]serviceHealth)
+	for _, line := range strings.Split(stdout, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		var state serviceHealth
+		if err := json.Unmarshal([]byte(line), &state); err != nil {
+			continue
+		}
+		statuses[state.Service] = state
+	}
+	return statuses
+}
@@ -0,0 +1,32 @@
+package compose
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// ValidateComposeFile runs `docker compose -f path config -q` in dir,
+// which fails immediately if path doesn't exist and otherwise fully
+// parses it, catching broken YAML or invalid compose syntax before a
+// workspace ever tries to run a real command against it. It shells out
+// directly rather than through a Repository, since this check runs
+// before a workspace's own compose file override takes effect.
+func ValidateComposeFile(ctx context.Context, dir, path string) error {
+	cmd := exec.CommandContext(ctx, "docker", "compose", "-f", path, "config", "-q")
+	cmd.Dir = dir
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		return fmt.Errorf("compose file %s is invalid: %s", path, msg)
+	}
+	return nil
+}
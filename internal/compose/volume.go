@@ -0,0 +1,115 @@
+package compose
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// snapshotDirName is the directory, relative to the workspace, where
+// volume snapshots are stored.
+const snapshotDirName = ".mcp-snapshots"
+
+// VolumeSnapshot records where a single volume's data was backed up to.
+type VolumeSnapshot struct {
+	Volume    string `json:"volume"`
+	Path      string `json:"path"`
+	CreatedAt string `json:"createdAt"`
+}
+
+// listProjectVolumes returns the named volumes declared by the compose
+// project in workDir.
+func listProjectVolumes(ctx context.Context, workDir string) ([]string, error) {
+	cmd := exec.CommandContext(ctx, "docker", "compose", "config", "--volumes")
+	cmd.Dir = workDir
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("list project volumes: %w: %s", err, stderr.String())
+	}
+
+	var volumes []string
+	for _, line := range strings.Split(stdout.String(), "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" {
+			volumes = append(volumes, line)
+		}
+	}
+	return volumes, nil
+}
+
+// snapshotVolume copies a named Docker volume's contents into a tarball
+// under destDir, using a throwaway Alpine container to read the volume.
+func snapshotVolume(ctx context.Context, volume, destDir string) (*VolumeSnapshot, error) {
+	if err := os.MkdirAll(destDir, 0o755); err != nil {
+		return nil, fmt.Errorf("create snapshot dir: %w", err)
+	}
+
+	archive := fmt.Sprintf("%s-%d.tar.gz", volume, time.Now().Unix())
+	archivePath := filepath.Join(destDir, archive)
+
+	cmd := exec.CommandContext(ctx, "docker", "run", "--rm",
+		"-v", volume+":/source:ro",
+		"-v", destDir+":/backup",
+		"alpine",
+		"tar", "czf", "/backup/"+archive, "-C", "/source", ".")
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("snapshot volume %s: %w: %s", volume, err, stderr.String())
+	}
+
+	return &VolumeSnapshot{Volume: volume, Path: archivePath, CreatedAt: time.Now().Format(time.RFC3339)}, nil
+}
+
+// restoreVolume extracts a snapshot tarball back into a named Docker
+// volume, overwriting its current contents.
+func restoreVolume(ctx context.Context, volume, snapshotPath string) error {
+	if _, err := os.Stat(snapshotPath); err != nil {
+		return fmt.Errorf("snapshot not found: %w", err)
+	}
+
+	snapshotDir := filepath.Dir(snapshotPath)
+	archive := filepath.Base(snapshotPath)
+
+	cmd := exec.CommandContext(ctx, "docker", "run", "--rm",
+		"-v", volume+":/target",
+		"-v", snapshotDir+":/backup:ro",
+		"alpine",
+		"tar", "xzf", "/backup/"+archive, "-C", "/target")
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("restore volume %s: %w: %s", volume, err, stderr.String())
+	}
+	return nil
+}
+
+// snapshotAllVolumes snapshots every volume declared by the project in
+// workDir, returning partial results alongside the first error.
+func snapshotAllVolumes(ctx context.Context, workDir string) ([]VolumeSnapshot, error) {
+	volumes, err := listProjectVolumes(ctx, workDir)
+	if err != nil {
+		return nil, err
+	}
+
+	destDir := filepath.Join(workDir, snapshotDirName)
+	var snapshots []VolumeSnapshot
+	for _, v := range volumes {
+		snap, err := snapshotVolume(ctx, v, destDir)
+		if err != nil {
+			return snapshots, err
+		}
+		snapshots = append(snapshots, *snap)
+	}
+	return snapshots, nil
+}
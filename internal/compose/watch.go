@@ -0,0 +1,41 @@
+package compose
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jontolof/docker-compose-mcp/internal/mcp"
+)
+
+// WatchParams are the arguments to the compose_watch tool.
+type WatchParams struct {
+	Services []string `json:"services,omitempty"`
+}
+
+// WatchStarted is returned immediately when a watch session begins; use
+// compose_session_read with the returned sessionId to stream its output.
+type WatchStarted struct {
+	SessionID string `json:"sessionId"`
+}
+
+func (c *Controller) handleWatch(ctx context.Context, args json.RawMessage) (*mcp.CallToolResult, error) {
+	var p WatchParams
+	if len(args) > 0 {
+		if err := json.Unmarshal(args, &p); err != nil {
+			return nil, fmt.Errorf("invalid compose_watch params: %w", err)
+		}
+	}
+
+	cliArgs := append([]string{"compose", "watch"}, p.Services...)
+	sess, err := c.sessions.Start(ctx, "compose_watch", c.dir(), "docker", cliArgs)
+	if err != nil {
+		return nil, fmt.Errorf("start watch session: %w", err)
+	}
+
+	payload, err := json.Marshal(WatchStarted{SessionID: sess.ID})
+	if err != nil {
+		return nil, err
+	}
+	return mcp.TextResult(string(payload)), nil
+}
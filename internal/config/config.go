@@ -0,0 +1,310 @@
+// Package config centralizes server configuration: which optional
+// subsystems are enabled and their key tuning parameters. Values are
+// read from environment variables so the server can be configured the
+// same way across deployment methods (Claude Desktop config, shell,
+// CI).
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Config holds the resolved, effective server configuration.
+type Config struct {
+	CacheEnabled bool `json:"cacheEnabled"`
+	CacheMaxSize int  `json:"cacheMaxSize"`
+
+	MetricsEnabled         bool    `json:"metricsEnabled"`
+	MetricsTargetReduction float64 `json:"metricsTargetReduction"`
+	// MetricsCharsPerToken and MetricsCostPerToken feed
+	// filter.FilterMetrics.SetCostModel, estimating the dollar cost
+	// filtering saves. Defaults match common model pricing; operators
+	// with different rates should override them.
+	MetricsCharsPerToken float64 `json:"metricsCharsPerToken"`
+	MetricsCostPerToken  float64 `json:"metricsCostPerToken"`
+
+	ParallelEnabled bool `json:"parallelEnabled"`
+	MaxWorkers      int  `json:"maxWorkers"`
+
+	PluginsEnabled          bool `json:"pluginsEnabled"`
+	PluginCount             int  `json:"pluginCount"`
+	PluginGitInstallEnabled bool `json:"pluginGitInstallEnabled"`
+	// PluginHookTimeout bounds, in seconds, how long FireEvent waits for
+	// a single plugin's event hook before counting it as slow and moving
+	// on to the next plugin.
+	PluginHookTimeout int `json:"pluginHookTimeout"`
+	// PluginHotReloadEnabled watches the plugin search paths and
+	// reloads a plugin automatically when its binary changes on disk.
+	PluginHotReloadEnabled bool `json:"pluginHotReloadEnabled"`
+	// Environment names the deployment environment (e.g. "development",
+	// "production") a per-plugin Config.Environments override applies
+	// to. Empty means no environment-specific overrides are applied.
+	Environment string `json:"environment"`
+
+	ProfilingEnabled bool `json:"profilingEnabled"`
+
+	MultiHostEnabled bool `json:"multiHostEnabled"`
+
+	QueueModeEnabled bool `json:"queueModeEnabled"`
+
+	// DryRunDefault makes every compose tool behave as if dryRun were
+	// set, regardless of what the caller passed, so an operator can
+	// force preview-only mode for a whole session.
+	DryRunDefault bool `json:"dryRunDefault"`
+
+	// LogFile, if set, directs diagnostic log output to a rotating file
+	// instead of stderr. LogMaxSize (bytes) and LogMaxBackups configure
+	// that rotation; see logging.NewFileLogger.
+	LogFile       string `json:"logFile"`
+	LogMaxSize    int64  `json:"logMaxSize"`
+	LogMaxBackups int    `json:"logMaxBackups"`
+
+	// AllowedCommands restricts which compose subcommands may run. An
+	// empty list leaves every command allowed.
+	AllowedCommands []string `json:"allowedCommands"`
+	// RestrictedPaths blocks handlers from reading or writing inside
+	// these directories, e.g. to stop a prompt from overwriting system
+	// files via compose_cp or an exec working directory.
+	RestrictedPaths []string `json:"restrictedPaths"`
+
+	// CommandTimeout bounds how long a single compose invocation may run,
+	// in seconds. MaxCommandTimeout caps how far a per-call "timeout"
+	// override (see compose.Service.Execute) can raise it.
+	CommandTimeout    int `json:"commandTimeout"`
+	MaxCommandTimeout int `json:"maxCommandTimeout"`
+
+	// RetryCount is how many extra attempts Execute makes for read-only
+	// compose commands after a transient Docker daemon error. RetryDelay
+	// is the base backoff between attempts, in seconds, multiplied by
+	// the attempt number. Mutating commands are never retried.
+	RetryCount int `json:"retryCount"`
+	RetryDelay int `json:"retryDelay"`
+
+	// ProjectName overrides compose's own project name derivation (the
+	// working directory's base name by default) via `-p`, so multiple
+	// checkouts of the same compose file don't collide. A per-call
+	// project_name param takes precedence over this; compose's own
+	// default applies when neither is set.
+	ProjectName string `json:"projectName"`
+
+	// SessionTimeout stops background sessions (compose_watch, follow
+	// logs, etc.) that produce no new output and go unread for this
+	// long, in seconds. MaxSessions caps how many may be active at
+	// once; CreateSession rejects new sessions past that. Either may be
+	// 0 to disable the corresponding limit.
+	SessionTimeout int `json:"sessionTimeout"`
+	MaxSessions    int `json:"maxSessions"`
+
+	// ShutdownTimeout bounds, in seconds, how long the server waits for
+	// in-flight compose commands to finish on exit before cancelling
+	// them outright.
+	ShutdownTimeout int `json:"shutdownTimeout"`
+
+	// RedactPatterns are extra regexes, beyond the filter's built-in
+	// secret patterns, whose matches get masked out of compose output.
+	RedactPatterns []string `json:"redactPatterns"`
+
+	// FilterMaxBytes caps how large a single filtered command result
+	// may be before the filter truncates it, keeping the head and
+	// tail. 0 or less disables the cap.
+	FilterMaxBytes int `json:"filterMaxBytes"`
+
+	// ComposeValidationStrict controls what workspace_set_compose_file
+	// does when the compose file fails `docker compose config -q`: if
+	// true, it rejects the change; if false, it still applies the
+	// change but reports the validation failure as a warning.
+	ComposeValidationStrict bool `json:"composeValidationStrict"`
+}
+
+// IsCommandAllowed reports whether cmd (a compose subcommand such as
+// "up" or "down") is permitted to run. An empty AllowedCommands list
+// permits every command.
+func (c *Config) IsCommandAllowed(cmd string) bool {
+	if len(c.AllowedCommands) == 0 {
+		return true
+	}
+	for _, allowed := range c.AllowedCommands {
+		if allowed == cmd {
+			return true
+		}
+	}
+	return false
+}
+
+// IsPathRestricted reports whether path falls within one of
+// RestrictedPaths, resolving it to an absolute path first so relative
+// paths and "." components can't be used to slip past the check.
+func (c *Config) IsPathRestricted(path string) bool {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		abs = path
+	}
+	for _, restricted := range c.RestrictedPaths {
+		restrictedAbs, err := filepath.Abs(restricted)
+		if err != nil {
+			restrictedAbs = restricted
+		}
+		if abs == restrictedAbs || strings.HasPrefix(abs, restrictedAbs+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Load builds a Config from environment variables, applying defaults
+// for anything unset. If MCP_CONFIG_FILE names a JSON file, its fields
+// are applied on top of the environment-derived config, so a config
+// file only needs to list the values it wants to override.
+func Load() *Config {
+	cfg := &Config{
+		CacheEnabled: envBool("MCP_CACHE_ENABLED", true),
+		CacheMaxSize: envInt("MCP_CACHE_MAX_SIZE", 100),
+
+		MetricsEnabled:         envBool("MCP_METRICS_ENABLED", true),
+		MetricsTargetReduction: envFloat("MCP_METRICS_TARGET_REDUCTION", 0.9),
+		MetricsCharsPerToken:   envFloat("MCP_TOKENS_CHARS_PER_TOKEN", 4.0),
+		MetricsCostPerToken:    envFloat("MCP_COST_PER_TOKEN", 0.000003),
+
+		ParallelEnabled: envBool("MCP_PARALLEL_ENABLED", true),
+		MaxWorkers:      envInt("MCP_MAX_WORKERS", 4),
+
+		PluginsEnabled:          envBool("MCP_PLUGINS_ENABLED", false),
+		PluginCount:             0,
+		PluginGitInstallEnabled: envBool("MCP_PLUGIN_GIT_INSTALL_ENABLED", false),
+		PluginHookTimeout:       envInt("MCP_PLUGIN_HOOK_TIMEOUT", 10),
+		PluginHotReloadEnabled:  envBool("MCP_PLUGIN_HOT_RELOAD_ENABLED", false),
+		Environment:             os.Getenv("MCP_ENVIRONMENT"),
+
+		ProfilingEnabled: envBool("MCP_PROFILING_ENABLED", false),
+
+		MultiHostEnabled: envBool("MCP_MULTI_HOST_ENABLED", false),
+
+		QueueModeEnabled: envBool("MCP_QUEUE_MODE_ENABLED", false),
+		DryRunDefault:    envBool("MCP_DRY_RUN", false),
+
+		AllowedCommands: envList("MCP_ALLOWED_COMMANDS", nil),
+		RestrictedPaths: envList("MCP_RESTRICTED_PATHS", []string{"/etc", "/boot", "/sys", "/proc"}),
+
+		LogFile:       os.Getenv("MCP_LOG_FILE"),
+		LogMaxSize:    envInt64("MCP_LOG_MAX_SIZE", 10*1024*1024),
+		LogMaxBackups: envInt("MCP_LOG_MAX_BACKUPS", 3),
+
+		CommandTimeout:    envInt("MCP_COMMAND_TIMEOUT", 300),
+		MaxCommandTimeout: envInt("MCP_MAX_COMMAND_TIMEOUT", 900),
+
+		RetryCount: envInt("MCP_RETRY_COUNT", 2),
+		RetryDelay: envInt("MCP_RETRY_DELAY", 1),
+
+		ProjectName: os.Getenv("MCP_PROJECT_NAME"),
+
+		SessionTimeout: envInt("MCP_SESSION_TIMEOUT", 1800),
+		MaxSessions:    envInt("MCP_MAX_SESSIONS", 10),
+
+		ShutdownTimeout: envInt("MCP_SHUTDOWN_TIMEOUT", 30),
+
+		RedactPatterns: envList("MCP_REDACT_PATTERNS", nil),
+
+		FilterMaxBytes: envInt("MCP_FILTER_MAX_BYTES", 200_000),
+
+		ComposeValidationStrict: envBool("MCP_COMPOSE_VALIDATION_STRICT", true),
+	}
+
+	if path := os.Getenv("MCP_CONFIG_FILE"); path != "" {
+		if err := loadFromFile(path, cfg); err != nil {
+			fmt.Fprintf(os.Stderr, "config: %v\n", err)
+		}
+	}
+
+	return cfg
+}
+
+// loadFromFile reads the config file at path and applies any fields it
+// sets onto cfg. Fields the file omits are left untouched, so callers
+// can populate cfg with defaults first and overlay only what the file
+// specifies. JSON (.json) and a flat YAML subset (.yaml, .yml) are both
+// supported; any other extension is treated as JSON.
+func loadFromFile(path string, cfg *Config) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read config file: %w", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		if err := applyYAML(data, cfg); err != nil {
+			return fmt.Errorf("parse config file %s: %w", path, err)
+		}
+	default:
+		if err := json.Unmarshal(data, cfg); err != nil {
+			return fmt.Errorf("parse config file %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+func envBool(key string, def bool) bool {
+	v, ok := os.LookupEnv(key)
+	if !ok {
+		return def
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return def
+	}
+	return b
+}
+
+func envInt(key string, def int) int {
+	v, ok := os.LookupEnv(key)
+	if !ok {
+		return def
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return def
+	}
+	return n
+}
+
+func envList(key string, def []string) []string {
+	v, ok := os.LookupEnv(key)
+	if !ok || v == "" {
+		return def
+	}
+	var out []string
+	for _, part := range strings.Split(v, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
+func envInt64(key string, def int64) int64 {
+	v, ok := os.LookupEnv(key)
+	if !ok {
+		return def
+	}
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return def
+	}
+	return n
+}
+
+func envFloat(key string, def float64) float64 {
+	v, ok := os.LookupEnv(key)
+	if !ok {
+		return def
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return def
+	}
+	return f
+}
@@ -0,0 +1,62 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadFromFileJSON(t *testing.T) {
+	cfg := &Config{CacheEnabled: true, CacheMaxSize: 100}
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	data, err := json.Marshal(map[string]interface{}{"cacheMaxSize": 250, "projectName": "demo"})
+	if err != nil {
+		t.Fatalf("marshal sample config: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("write sample config: %v", err)
+	}
+
+	if err := loadFromFile(path, cfg); err != nil {
+		t.Fatalf("loadFromFile: %v", err)
+	}
+	if cfg.CacheMaxSize != 250 {
+		t.Errorf("CacheMaxSize = %d, want 250", cfg.CacheMaxSize)
+	}
+	if cfg.ProjectName != "demo" {
+		t.Errorf("ProjectName = %q, want %q", cfg.ProjectName, "demo")
+	}
+	if !cfg.CacheEnabled {
+		t.Error("CacheEnabled should be left untouched by a file that doesn't mention it")
+	}
+}
+
+func TestLoadFromFileYAML(t *testing.T) {
+	yaml := "# sample override\ncacheMaxSize: 300\nprojectName: demo-yaml\n"
+	for _, ext := range []string{"yaml", "yml"} {
+		path := filepath.Join(t.TempDir(), "config."+ext)
+		if err := os.WriteFile(path, []byte(yaml), 0o644); err != nil {
+			t.Fatalf("write sample config: %v", err)
+		}
+
+		got := &Config{CacheEnabled: true, CacheMaxSize: 100}
+		if err := loadFromFile(path, got); err != nil {
+			t.Fatalf("loadFromFile(%s): %v", ext, err)
+		}
+		if got.CacheMaxSize != 300 {
+			t.Errorf("%s: CacheMaxSize = %d, want 300", ext, got.CacheMaxSize)
+		}
+		if got.ProjectName != "demo-yaml" {
+			t.Errorf("%s: ProjectName = %q, want %q", ext, got.ProjectName, "demo-yaml")
+		}
+	}
+}
+
+func TestLoadFromFileUnreadable(t *testing.T) {
+	cfg := &Config{}
+	if err := loadFromFile(filepath.Join(t.TempDir(), "missing.json"), cfg); err == nil {
+		t.Fatal("loadFromFile with a missing path should return an error")
+	}
+}
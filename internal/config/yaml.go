@@ -0,0 +1,178 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parseFlatYAML parses a minimal, flat subset of YAML: one `key: value`
+// pair per line, blank lines and `#` comments ignored. It does not
+// support nesting, lists, or multi-document files, since a config file
+// only ever needs a flat set of scalar overrides.
+func parseFlatYAML(data []byte) (map[string]string, error) {
+	values := make(map[string]string)
+
+	for i, raw := range strings.Split(string(data), "\n") {
+		line := raw
+		if idx := strings.Index(line, "#"); idx != -1 {
+			line = line[:idx]
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		sep := strings.Index(line, ":")
+		if sep == -1 {
+			return nil, fmt.Errorf("line %d: expected \"key: value\"", i+1)
+		}
+		key := strings.TrimSpace(line[:sep])
+		value := strings.TrimSpace(line[sep+1:])
+		value = strings.Trim(value, `"'`)
+		values[key] = value
+	}
+
+	return values, nil
+}
+
+// applyYAML overlays the scalar values parsed from a flat YAML config
+// file onto cfg, using the same field names as the JSON form.
+func applyYAML(data []byte, cfg *Config) error {
+	values, err := parseFlatYAML(data)
+	if err != nil {
+		return err
+	}
+
+	for key, value := range values {
+		if err := setField(cfg, key, value); err != nil {
+			return fmt.Errorf("key %q: %w", key, err)
+		}
+	}
+	return nil
+}
+
+func setField(cfg *Config, key, value string) error {
+	switch key {
+	case "cacheEnabled":
+		return setBool(&cfg.CacheEnabled, value)
+	case "cacheMaxSize":
+		return setInt(&cfg.CacheMaxSize, value)
+	case "metricsEnabled":
+		return setBool(&cfg.MetricsEnabled, value)
+	case "metricsTargetReduction":
+		return setFloat(&cfg.MetricsTargetReduction, value)
+	case "metricsCharsPerToken":
+		return setFloat(&cfg.MetricsCharsPerToken, value)
+	case "metricsCostPerToken":
+		return setFloat(&cfg.MetricsCostPerToken, value)
+	case "parallelEnabled":
+		return setBool(&cfg.ParallelEnabled, value)
+	case "maxWorkers":
+		return setInt(&cfg.MaxWorkers, value)
+	case "pluginsEnabled":
+		return setBool(&cfg.PluginsEnabled, value)
+	case "pluginCount":
+		return setInt(&cfg.PluginCount, value)
+	case "pluginGitInstallEnabled":
+		return setBool(&cfg.PluginGitInstallEnabled, value)
+	case "pluginHookTimeout":
+		return setInt(&cfg.PluginHookTimeout, value)
+	case "pluginHotReloadEnabled":
+		return setBool(&cfg.PluginHotReloadEnabled, value)
+	case "environment":
+		cfg.Environment = value
+		return nil
+	case "profilingEnabled":
+		return setBool(&cfg.ProfilingEnabled, value)
+	case "multiHostEnabled":
+		return setBool(&cfg.MultiHostEnabled, value)
+	case "queueModeEnabled":
+		return setBool(&cfg.QueueModeEnabled, value)
+	case "dryRunDefault":
+		return setBool(&cfg.DryRunDefault, value)
+	case "allowedCommands":
+		cfg.AllowedCommands = splitList(value)
+		return nil
+	case "restrictedPaths":
+		cfg.RestrictedPaths = splitList(value)
+		return nil
+	case "logFile":
+		cfg.LogFile = value
+		return nil
+	case "logMaxSize":
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		cfg.LogMaxSize = n
+		return nil
+	case "logMaxBackups":
+		return setInt(&cfg.LogMaxBackups, value)
+	case "commandTimeout":
+		return setInt(&cfg.CommandTimeout, value)
+	case "maxCommandTimeout":
+		return setInt(&cfg.MaxCommandTimeout, value)
+	case "retryCount":
+		return setInt(&cfg.RetryCount, value)
+	case "retryDelay":
+		return setInt(&cfg.RetryDelay, value)
+	case "projectName":
+		cfg.ProjectName = value
+		return nil
+	case "sessionTimeout":
+		return setInt(&cfg.SessionTimeout, value)
+	case "maxSessions":
+		return setInt(&cfg.MaxSessions, value)
+	case "shutdownTimeout":
+		return setInt(&cfg.ShutdownTimeout, value)
+	case "redactPatterns":
+		cfg.RedactPatterns = splitList(value)
+		return nil
+	case "filterMaxBytes":
+		return setInt(&cfg.FilterMaxBytes, value)
+	case "composeValidationStrict":
+		return setBool(&cfg.ComposeValidationStrict, value)
+	default:
+		return fmt.Errorf("unknown config field")
+	}
+}
+
+// splitList parses a comma-separated flat-YAML scalar into a list of
+// trimmed, non-empty values.
+func splitList(value string) []string {
+	var out []string
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
+func setBool(dst *bool, value string) error {
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return err
+	}
+	*dst = b
+	return nil
+}
+
+func setInt(dst *int, value string) error {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return err
+	}
+	*dst = n
+	return nil
+}
+
+func setFloat(dst *float64, value string) error {
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return err
+	}
+	*dst = f
+	return nil
+}
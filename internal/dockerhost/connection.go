@@ -0,0 +1,76 @@
+package dockerhost
+
+import (
+	"bytes"
+	"context"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// sshConnectTimeout bounds how long TestConnection waits for the SSH
+// handshake it runs against ssh:// hosts, on top of the `docker
+// version` check.
+const sshConnectTimeout = 5 * time.Second
+
+// ConnectionTestResult reports whether a Docker context's daemon could
+// be reached, and how long the check took.
+type ConnectionTestResult struct {
+	Context   string `json:"context"`
+	Reachable bool   `json:"reachable"`
+	Latency   string `json:"latency,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// TestConnection checks whether the Docker daemon behind the named
+// context responds, by running a cheap `docker version` against it
+// rather than just validating the context's endpoint syntax. If name
+// matches a manually configured ssh:// host, an SSH handshake is also
+// attempted via CheckSSHEndpoint, since a host can fail the SSH
+// handshake in ways `docker version` reports as an opaque timeout.
+func (m *HostManager) TestConnection(ctx context.Context, name string) ConnectionTestResult {
+	start := time.Now()
+
+	cmd := exec.CommandContext(ctx, "docker", "--context", name, "version", "--format", "{{.Server.Version}}")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	result := ConnectionTestResult{
+		Context:   name,
+		Reachable: err == nil,
+		Latency:   time.Since(start).String(),
+	}
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			result.Error = msg
+		} else {
+			result.Error = err.Error()
+		}
+	}
+
+	if host, ok := m.findHost(name); ok && strings.HasPrefix(host.Endpoint, "ssh://") {
+		sshResult, sshErr := CheckSSHEndpoint(ctx, host.Endpoint, sshConnectTimeout)
+		if sshErr == nil && !sshResult.Reachable {
+			result.Reachable = false
+			if result.Error == "" {
+				result.Error = sshResult.Detail
+			}
+		}
+	}
+
+	result.Latency = time.Since(start).String()
+	return result
+}
+
+// findHost looks up a manually configured host by name.
+func (m *HostManager) findHost(name string) (Host, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, h := range m.hosts {
+		if h.Name == name {
+			return h, true
+		}
+	}
+	return Host{}, false
+}
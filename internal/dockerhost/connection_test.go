@@ -0,0 +1,38 @@
+package dockerhost
+
+import (
+	"context"
+	"testing"
+)
+
+func TestTestConnectionHealthy(t *testing.T) {
+	fakeDockerOnPath(t, `
+if [ "$1" = "--context" ]; then
+  echo "24.0.0"
+  exit 0
+fi
+exit 1
+`)
+
+	m := NewHostManager("")
+	result := m.TestConnection(context.Background(), "default")
+	if !result.Reachable {
+		t.Fatalf("result = %+v, want Reachable true", result)
+	}
+	if result.Context != "default" {
+		t.Errorf("Context = %q, want %q", result.Context, "default")
+	}
+}
+
+func TestTestConnectionUnreachable(t *testing.T) {
+	fakeDockerOnPath(t, `echo "error during connect" >&2; exit 1`)
+
+	m := NewHostManager("")
+	result := m.TestConnection(context.Background(), "default")
+	if result.Reachable {
+		t.Fatal("result.Reachable = true, want false for a failing docker version check")
+	}
+	if result.Error == "" {
+		t.Error("result.Error should carry the docker CLI's stderr")
+	}
+}
@@ -0,0 +1,59 @@
+package dockerhost
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// fakeDockerOnPath writes an executable shell script named "docker" into
+// a temp directory, prepends it to PATH for the duration of the test,
+// and returns once the test finishes restoring the original PATH.
+func fakeDockerOnPath(t *testing.T, script string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake docker script requires a POSIX shell")
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "docker")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script+"\n"), 0o755); err != nil {
+		t.Fatalf("write fake docker script: %v", err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func TestDiscoverContexts(t *testing.T) {
+	fakeDockerOnPath(t, `
+if [ "$1 $2 $3" = "context ls --format" ]; then
+  echo '{"Name":"default","Description":"default context","DockerEndpoint":"unix:///var/run/docker.sock","Current":true}'
+  echo '{"Name":"remote","Description":"","DockerEndpoint":"ssh://user@host","Current":false}'
+  exit 0
+fi
+exit 1
+`)
+
+	contexts, err := discoverContexts(context.Background())
+	if err != nil {
+		t.Fatalf("discoverContexts: %v", err)
+	}
+	if len(contexts) != 2 {
+		t.Fatalf("len(contexts) = %d, want 2", len(contexts))
+	}
+	if contexts[0].Name != "default" || !contexts[0].Current {
+		t.Errorf("contexts[0] = %+v, want default/current", contexts[0])
+	}
+	if contexts[1].Name != "remote" || contexts[1].DockerEndpoint != "ssh://user@host" {
+		t.Errorf("contexts[1] = %+v, want remote/ssh endpoint", contexts[1])
+	}
+}
+
+func TestDiscoverContextsCommandError(t *testing.T) {
+	fakeDockerOnPath(t, `echo "docker: command not found" >&2; exit 127`)
+
+	if _, err := discoverContexts(context.Background()); err == nil {
+		t.Fatal("discoverContexts should return a descriptive error when the docker CLI fails")
+	}
+}
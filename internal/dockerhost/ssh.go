@@ -0,0 +1,109 @@
+package dockerhost
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SSHCheckResult reports whether an SSH-backed Docker context's host
+// actually completed an SSH handshake, as opposed to merely accepting a
+// TCP connection on the port.
+type SSHCheckResult struct {
+	Host      string `json:"host"`
+	Reachable bool   `json:"reachable"`
+	Detail    string `json:"detail,omitempty"`
+}
+
+// sshUnreachablePatterns are substrings of OpenSSH client errors that
+// indicate the handshake never started, i.e. the host is actually
+// unreachable rather than merely refusing this client.
+var sshUnreachablePatterns = []string{
+	"connection refused",
+	"no route to host",
+	"could not resolve hostname",
+	"connection timed out",
+	"network is unreachable",
+}
+
+// CheckSSHEndpoint validates an ssh:// Docker context endpoint by
+// actually attempting an SSH handshake against it, rather than just
+// dialing the TCP port: a host that accepts TCP connections but isn't
+// running an SSH server (or requires interactive auth) is reported
+// correctly instead of as reachable.
+func CheckSSHEndpoint(ctx context.Context, endpoint string, timeout time.Duration) (SSHCheckResult, error) {
+	target, port, err := parseSSHEndpoint(endpoint)
+	if err != nil {
+		return SSHCheckResult{}, err
+	}
+
+	args := []string{
+		"-o", "BatchMode=yes",
+		"-o", fmt.Sprintf("ConnectTimeout=%d", int(timeout.Seconds())),
+	}
+	if port != "" {
+		args = append(args, "-p", port)
+	}
+	args = append(args, target, "true")
+
+	cmd := exec.CommandContext(ctx, "ssh", args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	runErr := cmd.Run()
+	result := SSHCheckResult{Host: target}
+
+	if runErr == nil {
+		result.Reachable = true
+		return result, nil
+	}
+
+	detail := strings.TrimSpace(stderr.String())
+	result.Detail = detail
+	lower := strings.ToLower(detail)
+	for _, pattern := range sshUnreachablePatterns {
+		if strings.Contains(lower, pattern) {
+			result.Reachable = false
+			return result, nil
+		}
+	}
+
+	// Any other failure (permission denied, host key rejected, no
+	// shell) still means the SSH handshake itself succeeded.
+	result.Reachable = true
+	return result, nil
+}
+
+// parseSSHEndpoint extracts the "user@host" target and port from a
+// Docker ssh:// context endpoint.
+func parseSSHEndpoint(endpoint string) (target string, port string, err error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", "", fmt.Errorf("parse ssh endpoint %q: %w", endpoint, err)
+	}
+	if u.Scheme != "ssh" {
+		return "", "", fmt.Errorf("not an ssh endpoint: %q", endpoint)
+	}
+	if u.Hostname() == "" {
+		return "", "", fmt.Errorf("ssh endpoint %q has no host", endpoint)
+	}
+
+	target = u.Hostname()
+	if u.User != nil && u.User.Username() != "" {
+		target = u.User.Username() + "@" + target
+	}
+
+	if p := u.Port(); p != "" {
+		if _, err := strconv.Atoi(p); err != nil {
+			return "", "", fmt.Errorf("ssh endpoint %q has invalid port: %w", endpoint, err)
+		}
+		port = p
+	}
+
+	return target, port, nil
+}
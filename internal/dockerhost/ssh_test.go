@@ -0,0 +1,78 @@
+package dockerhost
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func fakeSSHOnPath(t *testing.T, script string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake ssh script requires a POSIX shell")
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "ssh")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script+"\n"), 0o755); err != nil {
+		t.Fatalf("write fake ssh script: %v", err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func TestCheckSSHEndpointReachable(t *testing.T) {
+	fakeSSHOnPath(t, "exit 0")
+
+	result, err := CheckSSHEndpoint(context.Background(), "ssh://user@example.com:2222", time.Second)
+	if err != nil {
+		t.Fatalf("CheckSSHEndpoint: %v", err)
+	}
+	if !result.Reachable {
+		t.Fatalf("result = %+v, want Reachable true", result)
+	}
+	if result.Host != "user@example.com" {
+		t.Errorf("Host = %q, want %q", result.Host, "user@example.com")
+	}
+}
+
+func TestCheckSSHEndpointUnreachable(t *testing.T) {
+	fakeSSHOnPath(t, `echo "ssh: connect to host example.com port 22: Connection refused" >&2; exit 255`)
+
+	result, err := CheckSSHEndpoint(context.Background(), "ssh://example.com", time.Second)
+	if err != nil {
+		t.Fatalf("CheckSSHEndpoint: %v", err)
+	}
+	if result.Reachable {
+		t.Fatal("result.Reachable = true, want false for connection refused")
+	}
+}
+
+func TestCheckSSHEndpointAuthFailureStillReachable(t *testing.T) {
+	fakeSSHOnPath(t, `echo "Permission denied (publickey)." >&2; exit 255`)
+
+	result, err := CheckSSHEndpoint(context.Background(), "ssh://example.com", time.Second)
+	if err != nil {
+		t.Fatalf("CheckSSHEndpoint: %v", err)
+	}
+	if !result.Reachable {
+		t.Fatal("result.Reachable = false, want true: the handshake itself succeeded despite auth failing")
+	}
+}
+
+func TestTestConnectionWiresSSHCheck(t *testing.T) {
+	fakeDockerOnPath(t, `echo "timeout" >&2; exit 1`)
+	fakeSSHOnPath(t, `echo "no route to host" >&2; exit 255`)
+
+	m := NewHostManager("")
+	if err := m.AddHost(Host{Name: "remote", Endpoint: "ssh://user@example.com"}); err != nil {
+		t.Fatalf("AddHost: %v", err)
+	}
+
+	result := m.TestConnection(context.Background(), "remote")
+	if result.Reachable {
+		t.Fatal("result.Reachable = true, want false: the SSH handshake itself never completed")
+	}
+}
@@ -0,0 +1,56 @@
+package dockerhost
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// VersionInfo reports the Docker client and server (daemon) versions
+// for the currently active context.
+type VersionInfo struct {
+	ClientVersion string `json:"clientVersion"`
+	ServerVersion string `json:"serverVersion"`
+}
+
+// rawVersion mirrors the subset of `docker version --format json` this
+// package reads.
+type rawVersion struct {
+	Client struct {
+		Version string `json:"Version"`
+	} `json:"Client"`
+	Server struct {
+		Version string `json:"Version"`
+	} `json:"Server"`
+}
+
+// Version queries the Docker daemon behind the active context for its
+// version, rather than assuming it matches the client.
+func (m *HostManager) Version(ctx context.Context) (VersionInfo, error) {
+	return getDockerVersion(ctx)
+}
+
+func getDockerVersion(ctx context.Context) (VersionInfo, error) {
+	cmd := exec.CommandContext(ctx, "docker", "version", "--format", "json")
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return VersionInfo{}, fmt.Errorf("docker version: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	var raw rawVersion
+	if err := json.Unmarshal(stdout.Bytes(), &raw); err != nil {
+		return VersionInfo{}, fmt.Errorf("parse docker version output: %w", err)
+	}
+
+	return VersionInfo{
+		ClientVersion: raw.Client.Version,
+		ServerVersion: raw.Server.Version,
+	}, nil
+}
@@ -0,0 +1,162 @@
+// Package executor provides a bounded worker pool used to run Docker
+// Compose operations concurrently while respecting a configured
+// concurrency limit.
+package executor
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+)
+
+// Job is a unit of work submitted to an Executor. Priority controls
+// scheduling order when more jobs are pending than there are idle
+// workers: higher-priority jobs run first, and jobs of equal priority
+// run in the order they were submitted.
+type Job struct {
+	ID       string
+	Priority int
+	Fn       func(ctx context.Context) (interface{}, error)
+}
+
+// Result is the outcome of running a Job.
+type Result struct {
+	ID    string
+	Value interface{}
+	Err   error
+}
+
+// Executor runs submitted jobs across a fixed-size pool of workers,
+// dispatching the highest-priority pending job whenever a worker is
+// free.
+type Executor struct {
+	intake  chan Job
+	jobs    chan Job
+	results chan Result
+	wg      sync.WaitGroup
+}
+
+// New creates an Executor with workers concurrent goroutines. A
+// non-positive workers defaults to 1.
+func New(workers int) *Executor {
+	if workers <= 0 {
+		workers = 1
+	}
+	e := &Executor{
+		intake:  make(chan Job),
+		jobs:    make(chan Job),
+		results: make(chan Result),
+	}
+	go e.dispatch()
+	for i := 0; i < workers; i++ {
+		e.wg.Add(1)
+		go e.worker()
+	}
+	return e
+}
+
+// dispatch reorders jobs submitted to intake by priority before handing
+// them to workers via jobs, so a flood of low-priority submissions
+// can't delay a high-priority job that arrives after them but before a
+// worker frees up.
+func (e *Executor) dispatch() {
+	var pq priorityQueue
+	intakeOpen := true
+
+	for intakeOpen || pq.Len() > 0 {
+		if pq.Len() == 0 {
+			job, ok := <-e.intake
+			if !ok {
+				intakeOpen = false
+				continue
+			}
+			heap.Push(&pq, job)
+			continue
+		}
+
+		select {
+		case job, ok := <-e.intake:
+			if !ok {
+				intakeOpen = false
+				continue
+			}
+			heap.Push(&pq, job)
+		case e.jobs <- pq.peek():
+			heap.Pop(&pq)
+		}
+	}
+
+	close(e.jobs)
+}
+
+func (e *Executor) worker() {
+	defer e.wg.Done()
+	for job := range e.jobs {
+		value, err := job.Fn(context.Background())
+		e.results <- Result{ID: job.ID, Value: value, Err: err}
+	}
+}
+
+// Submit enqueues a job for execution. It blocks if all workers are busy.
+func (e *Executor) Submit(job Job) {
+	e.intake <- job
+}
+
+// Results returns the channel on which job results are delivered.
+func (e *Executor) Results() <-chan Result {
+	return e.results
+}
+
+// RunAll submits every job, waits for all of them to complete, and
+// returns their results in the order the jobs were given, regardless of
+// the priority order they actually ran in.
+func (e *Executor) RunAll(jobs []Job) []Result {
+	results := make(map[string]Result, len(jobs))
+	var mu sync.Mutex
+	var collected sync.WaitGroup
+	collected.Add(len(jobs))
+
+	go func() {
+		for i := 0; i < len(jobs); i++ {
+			r := <-e.results
+			mu.Lock()
+			results[r.ID] = r
+			mu.Unlock()
+			collected.Done()
+		}
+	}()
+
+	for _, job := range jobs {
+		e.Submit(job)
+	}
+	collected.Wait()
+
+	ordered := make([]Result, len(jobs))
+	for i, job := range jobs {
+		ordered[i] = results[job.ID]
+	}
+	return ordered
+}
+
+// Shutdown closes the job queue and waits for all workers to finish
+// in-flight jobs. A worker that finishes a job blocks trying to send
+// its Result until something reads from Results(); RunAll always does,
+// but a caller driving the pool directly via Submit may not have read
+// every result before calling Shutdown. Without draining those leftover
+// results here, the worker would block on that send forever and
+// e.wg.Wait() would never return. The drain goroutine exits once every
+// worker has in fact exited and results is closed behind them.
+func (e *Executor) Shutdown() {
+	close(e.intake)
+
+	drained := make(chan struct{})
+	go func() {
+		for range e.results {
+		}
+		close(drained)
+	}()
+
+	e.wg.Wait()
+	close(e.results)
+	<-drained
+}
@@ -0,0 +1,89 @@
+package executor
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestExecutorShutdownDrainsPendingResults(t *testing.T) {
+	e := New(2)
+
+	for i := 0; i < 5; i++ {
+		e.Submit(Job{
+			ID: string(rune('a' + i)),
+			Fn: func(ctx context.Context) (interface{}, error) {
+				return nil, nil
+			},
+		})
+	}
+
+	done := make(chan struct{})
+	go func() {
+		e.Shutdown()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Shutdown did not return; workers likely blocked sending unconsumed results")
+	}
+}
+
+// TestExecutorPriorityOrder holds the single worker busy on a blocking
+// job while several lower/higher priority jobs pile up in the dispatch
+// queue, then verifies they run in descending-priority order once the
+// worker frees up.
+func TestExecutorPriorityOrder(t *testing.T) {
+	e := New(1)
+	defer e.Shutdown()
+
+	block := make(chan struct{})
+	e.Submit(Job{
+		ID: "blocker",
+		Fn: func(ctx context.Context) (interface{}, error) {
+			<-block
+			return nil, nil
+		},
+	})
+
+	var mu sync.Mutex
+	var order []string
+	record := func(id string) func(ctx context.Context) (interface{}, error) {
+		return func(ctx context.Context) (interface{}, error) {
+			mu.Lock()
+			order = append(order, id)
+			mu.Unlock()
+			return nil, nil
+		}
+	}
+
+	submitted := []Job{
+		{ID: "low", Priority: 1, Fn: record("low")},
+		{ID: "high", Priority: 10, Fn: record("high")},
+		{ID: "mid", Priority: 5, Fn: record("mid")},
+	}
+	for _, job := range submitted {
+		e.Submit(job)
+	}
+	close(block)
+
+	<-e.Results() // the blocker job's own result
+	for range submitted {
+		<-e.Results()
+	}
+
+	want := []string{"high", "mid", "low"}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(order) != len(want) {
+		t.Fatalf("order = %v, want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("order = %v, want %v", order, want)
+		}
+	}
+}
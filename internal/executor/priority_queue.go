@@ -0,0 +1,49 @@
+package executor
+
+// priorityQueue orders Jobs by descending Priority, breaking ties by
+// submission order so jobs of equal priority still run FIFO. It
+// implements container/heap.Interface; seq gives each pushed job a
+// strictly increasing tiebreaker since Job itself carries no sequence
+// number.
+type priorityQueue struct {
+	items []queuedJob
+	seq   int
+}
+
+type queuedJob struct {
+	job Job
+	seq int
+}
+
+func (pq *priorityQueue) Len() int { return len(pq.items) }
+
+func (pq *priorityQueue) Less(i, j int) bool {
+	if pq.items[i].job.Priority != pq.items[j].job.Priority {
+		return pq.items[i].job.Priority > pq.items[j].job.Priority
+	}
+	return pq.items[i].seq < pq.items[j].seq
+}
+
+func (pq *priorityQueue) Swap(i, j int) {
+	pq.items[i], pq.items[j] = pq.items[j], pq.items[i]
+}
+
+func (pq *priorityQueue) Push(x interface{}) {
+	job := x.(Job)
+	pq.items = append(pq.items, queuedJob{job: job, seq: pq.seq})
+	pq.seq++
+}
+
+func (pq *priorityQueue) Pop() interface{} {
+	old := pq.items
+	n := len(old)
+	item := old[n-1]
+	pq.items = old[:n-1]
+	return item.job
+}
+
+// peek returns the highest-priority pending job without removing it.
+// The caller must ensure Len() > 0.
+func (pq *priorityQueue) peek() Job {
+	return pq.items[0].job
+}
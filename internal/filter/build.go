@@ -0,0 +1,44 @@
+package filter
+
+import (
+	"regexp"
+	"strings"
+)
+
+// buildNoisePatterns match lines that are pure Docker build progress noise:
+// layer pulls, download progress, and cache digest lines.
+var buildNoisePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`^#\d+ \d+\.\d+ `),
+	regexp.MustCompile(`(?i)^(Pulling|Waiting|Downloading|Extracting|Verifying Checksum|Download complete|Pull complete|Already exists)`),
+	regexp.MustCompile(`^=> `),
+}
+
+// FilterBuildOutput strips Docker build layer noise while preserving
+// errors, warnings, and the final build status line.
+func FilterBuildOutput(raw string) string {
+	lines := strings.Split(raw, "\n")
+	var out []string
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+		lower := strings.ToLower(trimmed)
+		if strings.Contains(lower, "error") || strings.Contains(lower, "warn") ||
+			strings.Contains(lower, "failed") || strings.Contains(lower, "successfully built") {
+			out = append(out, trimmed)
+			continue
+		}
+		noisy := false
+		for _, p := range buildNoisePatterns {
+			if p.MatchString(trimmed) {
+				noisy = true
+				break
+			}
+		}
+		if !noisy {
+			out = append(out, trimmed)
+		}
+	}
+	return strings.Join(out, "\n")
+}
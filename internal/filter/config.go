@@ -0,0 +1,46 @@
+package filter
+
+// Config describes the effective filtering behavior: which lines are
+// always kept, which are always dropped, and the threshold past which
+// general output is considered noisy.
+type Config struct {
+	KeepPatterns    []string `json:"keepPatterns"`
+	SkipPatterns    []string `json:"skipPatterns"`
+	LengthThreshold int      `json:"lengthThreshold"`
+}
+
+// DefaultConfig returns the filter configuration used when none is
+// explicitly supplied.
+func DefaultConfig() Config {
+	return Config{
+		KeepPatterns:    []string{"error", "warn", "fail", "fatal", "panic"},
+		SkipPatterns:    []string{"pulling", "downloading", "extracting", "already exists"},
+		LengthThreshold: 2000,
+	}
+}
+
+// EffectiveConfig returns the configuration used for the given command,
+// applying a per-command override when one is registered.
+func (f *Filter) EffectiveConfig(command string) Config {
+	if override, ok := f.overrides[command]; ok {
+		return override
+	}
+	return f.config
+}
+
+// SetOverride registers a per-command configuration override.
+func (f *Filter) SetOverride(command string, cfg Config) {
+	if f.overrides == nil {
+		f.overrides = make(map[string]Config)
+	}
+	f.overrides[command] = cfg
+}
+
+// Overrides returns a copy of the registered per-command overrides.
+func (f *Filter) Overrides() map[string]Config {
+	out := make(map[string]Config, len(f.overrides))
+	for k, v := range f.overrides {
+		out[k] = v
+	}
+	return out
+}
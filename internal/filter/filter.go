@@ -0,0 +1,91 @@
+// Package filter implements the output filtering engine that reduces
+// verbose Docker Compose output down to the information an AI assistant
+// actually needs: errors, warnings, and final status.
+package filter
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Kind identifies the category of output being filtered, since each kind
+// of Docker Compose output has its own noise patterns.
+type Kind string
+
+const (
+	KindGeneral Kind = "general"
+	KindBuild   Kind = "build"
+	KindTest    Kind = "test"
+	KindLogs    Kind = "logs"
+	KindImages  Kind = "images"
+	KindRaw     Kind = "raw"
+)
+
+// Filter reduces raw command output to its essential lines.
+type Filter struct {
+	config         Config
+	overrides      map[string]Config
+	redactPatterns []*regexp.Regexp
+	maxOutputBytes int
+}
+
+// New creates a Filter using DefaultConfig.
+func New() *Filter {
+	return &Filter{config: DefaultConfig(), maxOutputBytes: defaultMaxOutputBytes}
+}
+
+// SetMaxOutputBytes caps how large Apply's result may be before it gets
+// truncated (keeping the head and tail). 0 or less disables the cap.
+func (f *Filter) SetMaxOutputBytes(max int) {
+	f.maxOutputBytes = max
+}
+
+// SetRedactPatterns configures extra regexes, beyond the built-in
+// secret patterns, whose matches Apply masks before returning output.
+// Patterns that fail to compile are skipped; the rest still apply.
+func (f *Filter) SetRedactPatterns(patterns []string) []error {
+	compiled, errs := compileRedactPatterns(patterns)
+	f.redactPatterns = compiled
+	return errs
+}
+
+// Apply filters raw according to kind, then redacts anything in the
+// result that matches a known secret shape, so keep/skip filtering
+// never accidentally surfaces a credential it was told to keep.
+func (f *Filter) Apply(raw string, kind Kind) string {
+	var result string
+	switch kind {
+	case KindBuild:
+		result = FilterBuildOutput(raw)
+	case KindTest:
+		result = FilterTestOutput(raw)
+	case KindLogs:
+		result = FilterLogOutput(raw)
+	case KindImages:
+		result = FilterImageOutput(raw)
+	case KindRaw:
+		result = strings.TrimRight(raw, "\n")
+	default:
+		result = filterGeneral(raw)
+	}
+	result = redact(result, f.redactPatterns)
+	return truncateToBudget(result, f.maxOutputBytes)
+}
+
+// filterGeneral strips blank lines and Docker's carriage-return progress
+// updates, keeping only the last state of each line.
+func filterGeneral(raw string) string {
+	lines := strings.Split(raw, "\n")
+	var out []string
+	for _, line := range lines {
+		if idx := strings.LastIndex(line, "\r"); idx != -1 {
+			line = line[idx+1:]
+		}
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+		out = append(out, trimmed)
+	}
+	return strings.Join(out, "\n")
+}
@@ -0,0 +1,22 @@
+package filter
+
+import "strings"
+
+// FilterImageOutput trims blank lines from `docker compose images` output
+// but otherwise leaves it untouched, since every row (image, tag, size)
+// is information the caller asked for — there's no noise to strip the
+// way there is in build or log output.
+func FilterImageOutput(raw string) string {
+	lines := strings.Split(raw, "\n")
+	var out []string
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if idx := strings.LastIndex(line, "\r"); idx != -1 {
+			line = line[idx+1:]
+		}
+		out = append(out, line)
+	}
+	return strings.Join(out, "\n")
+}
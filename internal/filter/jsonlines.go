@@ -0,0 +1,53 @@
+package filter
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// FilterJSONLines reduces newline-delimited JSON output (as produced by
+// `--format json` / `--json` flags) to just keepKeys per line, re-emitted
+// as compact JSON. It exists because the line-length heuristics the rest
+// of this package uses to drop noise treat a long JSON object as
+// suspicious and would otherwise clip it; parsing the line instead lets
+// the caller shrink it safely. A line that isn't a JSON object passes
+// through unchanged, so a stray non-JSON line (a CLI warning printed to
+// the same stream) doesn't get dropped.
+func FilterJSONLines(raw string, keepKeys []string) string {
+	lines := strings.Split(raw, "\n")
+	out := make([]string, 0, len(lines))
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+		reduced, ok := keepJSONKeys(trimmed, keepKeys)
+		if !ok {
+			out = append(out, trimmed)
+			continue
+		}
+		out = append(out, reduced)
+	}
+	return strings.Join(out, "\n")
+}
+
+// keepJSONKeys parses line as a JSON object and re-encodes it with only
+// keepKeys retained. It reports false if line isn't a JSON object, so
+// the caller can fall back to passthrough.
+func keepJSONKeys(line string, keepKeys []string) (string, bool) {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(line), &obj); err != nil {
+		return "", false
+	}
+	filtered := make(map[string]json.RawMessage, len(keepKeys))
+	for _, key := range keepKeys {
+		if v, ok := obj[key]; ok {
+			filtered[key] = v
+		}
+	}
+	encoded, err := json.Marshal(filtered)
+	if err != nil {
+		return "", false
+	}
+	return string(encoded), true
+}
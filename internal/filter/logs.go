@@ -0,0 +1,23 @@
+package filter
+
+import "strings"
+
+// FilterLogOutput keeps log lines at WARN level or above along with
+// enough surrounding context to remain useful; INFO/DEBUG noise is
+// dropped.
+func FilterLogOutput(raw string) string {
+	lines := strings.Split(raw, "\n")
+	var out []string
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+		upper := strings.ToUpper(trimmed)
+		if strings.Contains(upper, "ERROR") || strings.Contains(upper, "WARN") ||
+			strings.Contains(upper, "FATAL") || strings.Contains(upper, "PANIC") {
+			out = append(out, trimmed)
+		}
+	}
+	return strings.Join(out, "\n")
+}
@@ -0,0 +1,156 @@
+package filter
+
+import (
+	"sync"
+	"time"
+)
+
+// defaultLatencyBounds are the upper bounds, in ascending order, of the
+// latency histogram buckets tracked per operation. The final bucket has
+// no upper bound and catches everything slower than the last value.
+var defaultLatencyBounds = []time.Duration{
+	100 * time.Millisecond,
+	500 * time.Millisecond,
+	1 * time.Second,
+	5 * time.Second,
+	30 * time.Second,
+}
+
+// OperationMetrics summarizes the filtering effectiveness and latency
+// of every recorded invocation of one compose tool.
+type OperationMetrics struct {
+	Count            int            `json:"count"`
+	InputBytes       int64          `json:"inputBytes"`
+	OutputBytes      int64          `json:"outputBytes"`
+	Latency          map[string]int `json:"latencyHistogram"`
+	EstimatedSavings float64        `json:"estimatedSavings"`
+}
+
+// ReductionRatio returns the fraction of input bytes removed by
+// filtering, in [0, 1]. It returns 0 if no input has been recorded yet.
+func (m OperationMetrics) ReductionRatio() float64 {
+	if m.InputBytes == 0 {
+		return 0
+	}
+	return 1 - float64(m.OutputBytes)/float64(m.InputBytes)
+}
+
+// Default cost-model constants, used until SetCostModel overrides them.
+// dollarsPerToken is a conservative placeholder; operators who know
+// their actual per-token model pricing should override both values.
+const (
+	defaultCharsPerToken   = 4.0
+	defaultDollarsPerToken = 0.000003
+)
+
+// FilterMetrics tracks, per compose tool, how much filtering reduced
+// output size and how long each invocation took, so the server can
+// report whether it's meeting its output-reduction target.
+type FilterMetrics struct {
+	mu              sync.Mutex
+	operations      map[string]*operationState
+	charsPerToken   float64
+	dollarsPerToken float64
+}
+
+type operationState struct {
+	count       int
+	inputBytes  int64
+	outputBytes int64
+	histogram   *latencyHistogram
+}
+
+// NewFilterMetrics creates an empty FilterMetrics, using
+// defaultCharsPerToken and defaultDollarsPerToken until SetCostModel
+// overrides them.
+func NewFilterMetrics() *FilterMetrics {
+	return &FilterMetrics{
+		operations:      make(map[string]*operationState),
+		charsPerToken:   defaultCharsPerToken,
+		dollarsPerToken: defaultDollarsPerToken,
+	}
+}
+
+// SetCostModel overrides the token-cost assumptions used to compute
+// OperationMetrics' EstimatedSavings: charsPerToken estimates how many
+// characters of filtered-out output make up one model token, and
+// dollarsPerToken prices that token. Model pricing varies, so callers
+// that know their actual rates should override the defaults.
+func (m *FilterMetrics) SetCostModel(charsPerToken, dollarsPerToken float64) {
+	m.mu.Lock()
+	m.charsPerToken = charsPerToken
+	m.dollarsPerToken = dollarsPerToken
+	m.mu.Unlock()
+}
+
+// Record logs one invocation of tool: the size of its raw output before
+// filtering, the size after, and how long the invocation took.
+func (m *FilterMetrics) Record(tool string, inputBytes, outputBytes int, latency time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	state, ok := m.operations[tool]
+	if !ok {
+		state = &operationState{histogram: newLatencyHistogram(defaultLatencyBounds)}
+		m.operations[tool] = state
+	}
+	state.count++
+	state.inputBytes += int64(inputBytes)
+	state.outputBytes += int64(outputBytes)
+	state.histogram.observe(latency)
+}
+
+// Snapshot returns a point-in-time copy of the metrics recorded for
+// every tool.
+func (m *FilterMetrics) Snapshot() map[string]OperationMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snapshot := make(map[string]OperationMetrics, len(m.operations))
+	for tool, state := range m.operations {
+		reduced := state.inputBytes - state.outputBytes
+		tokensSaved := float64(reduced) / m.charsPerToken
+		snapshot[tool] = OperationMetrics{
+			Count:            state.count,
+			InputBytes:       state.inputBytes,
+			OutputBytes:      state.outputBytes,
+			Latency:          state.histogram.snapshot(),
+			EstimatedSavings: tokensSaved * m.dollarsPerToken,
+		}
+	}
+	return snapshot
+}
+
+// latencyHistogram buckets observed durations by upper bound.
+type latencyHistogram struct {
+	bounds []time.Duration
+	counts []int
+}
+
+func newLatencyHistogram(bounds []time.Duration) *latencyHistogram {
+	return &latencyHistogram{
+		bounds: bounds,
+		counts: make([]int, len(bounds)+1),
+	}
+}
+
+func (h *latencyHistogram) observe(d time.Duration) {
+	for i, bound := range h.bounds {
+		if d <= bound {
+			h.counts[i]++
+			return
+		}
+	}
+	h.counts[len(h.counts)-1]++
+}
+
+// snapshot renders the histogram as a map keyed by each bucket's upper
+// bound (e.g. "<=500ms"), with the overflow bucket keyed "+Inf".
+func (h *latencyHistogram) snapshot() map[string]int {
+	out := make(map[string]int, len(h.counts))
+	for i, bound := range h.bounds {
+		out["<="+bound.String()] = h.counts[i]
+	}
+	out["+Inf"] = h.counts[len(h.counts)-1]
+	return out
+}
@@ -0,0 +1,30 @@
+package filter
+
+import (
+	"math"
+	"testing"
+)
+
+// floatEpsilon tolerates the last-bit rounding difference between a
+// compiler-folded constant expression and the same math performed as
+// sequential runtime float64 operations in Snapshot.
+const floatEpsilon = 1e-9
+
+func TestFilterMetricsSetCostModel(t *testing.T) {
+	m := NewFilterMetrics()
+	m.Record("compose_up", 1000, 100, 0)
+
+	snapshot := m.Snapshot()
+	before := snapshot["compose_up"].EstimatedSavings
+	wantBefore := (900.0 / defaultCharsPerToken) * defaultDollarsPerToken
+	if math.Abs(before-wantBefore) > floatEpsilon {
+		t.Fatalf("EstimatedSavings with defaults = %v, want %v", before, wantBefore)
+	}
+
+	m.SetCostModel(2, 0.00001)
+	after := m.Snapshot()["compose_up"].EstimatedSavings
+	wantAfter := (900.0 / 2) * 0.00001
+	if math.Abs(after-wantAfter) > floatEpsilon {
+		t.Fatalf("EstimatedSavings after SetCostModel(2, 0.00001) = %v, want %v", after, wantAfter)
+	}
+}
@@ -0,0 +1,61 @@
+package filter
+
+import "regexp"
+
+// redactedValue replaces whatever secret text matched.
+const redactedValue = "[REDACTED]"
+
+// builtinRedactPatterns match common secret shapes — cloud credentials,
+// bearer tokens, JWTs, and KEY=value env dumps — so compose and exec
+// output with secrets baked into the environment doesn't reach the
+// model or logs verbatim.
+var builtinRedactPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`\bAKIA[0-9A-Z]{16}\b`),
+	regexp.MustCompile(`(?i)\bBearer\s+[A-Za-z0-9\-._~+/]+=*`),
+	regexp.MustCompile(`\beyJ[A-Za-z0-9_-]+\.eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\b`),
+	regexp.MustCompile(`(?i)\b((?:password|passwd|secret|api[_-]?key)\w*)\s*[:=]\s*\S+`),
+}
+
+// redact masks secret-shaped substrings of s using the built-in
+// patterns plus any extra ones a caller configured. A pattern with a
+// capture group keeps the group (e.g. a KEY= prefix) and redacts only
+// the rest; a pattern without one redacts the whole match.
+func redact(s string, extra []*regexp.Regexp) string {
+	for _, pattern := range builtinRedactPatterns {
+		s = applyRedactPattern(s, pattern)
+	}
+	for _, pattern := range extra {
+		s = applyRedactPattern(s, pattern)
+	}
+	return s
+}
+
+func applyRedactPattern(s string, pattern *regexp.Regexp) string {
+	if pattern.NumSubexp() == 0 {
+		return pattern.ReplaceAllString(s, redactedValue)
+	}
+	return pattern.ReplaceAllStringFunc(s, func(match string) string {
+		groups := pattern.FindStringSubmatch(match)
+		if len(groups) > 1 && groups[1] != "" {
+			return groups[1] + "=" + redactedValue
+		}
+		return redactedValue
+	})
+}
+
+// compileRedactPatterns compiles each of patterns, skipping (and
+// reporting) any that don't parse as valid regexes rather than failing
+// the whole set.
+func compileRedactPatterns(patterns []string) ([]*regexp.Regexp, []error) {
+	var compiled []*regexp.Regexp
+	var errs []error
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, errs
+}
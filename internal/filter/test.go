@@ -0,0 +1,208 @@
+package filter
+
+import (
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// FilterTestOutput extracts failures and summary lines from test
+// output. It recognizes JUnit XML (pytest's --junitxml and jest's
+// jest-junit reporter), RSpec's default formatter, and `cargo test`
+// output, falling back to `go test` style plain text otherwise.
+func FilterTestOutput(raw string) string {
+	if looksLikeJUnitXML(raw) {
+		if filtered, err := FilterJUnitXML(raw); err == nil {
+			return filtered
+		}
+	}
+	if looksLikeRSpecOutput(raw) {
+		return FilterRSpecOutput(raw)
+	}
+	if looksLikeCargoTestOutput(raw) {
+		return FilterCargoTestOutput(raw)
+	}
+
+	lines := strings.Split(raw, "\n")
+	var out []string
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "--- PASS") {
+			continue
+		}
+		out = append(out, trimmed)
+	}
+	return strings.Join(out, "\n")
+}
+
+func looksLikeJUnitXML(raw string) bool {
+	trimmed := strings.TrimSpace(raw)
+	if trimmed == "" {
+		return false
+	}
+	head := trimmed
+	if len(head) > 200 {
+		head = head[:200]
+	}
+	return strings.HasPrefix(trimmed, "<?xml") || strings.Contains(head, "<testsuite")
+}
+
+// junitTestSuites matches a JUnit XML report whose root is <testsuites>
+// wrapping one or more <testsuite> elements; pytest and jest-junit both
+// emit this shape.
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitOutcome `xml:"failure"`
+	Error     *junitOutcome `xml:"error"`
+}
+
+type junitOutcome struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// FilterJUnitXML parses a JUnit XML test report and reduces it to its
+// failing/erroring test cases and a totals summary, discarding the
+// passing cases and stack-trace noise a full report carries.
+func FilterJUnitXML(raw string) (string, error) {
+	suites, err := parseJUnitXML(raw)
+	if err != nil {
+		return "", err
+	}
+
+	var out []string
+	var totalTests, totalFailures, totalErrors, totalSkipped int
+
+	for _, suite := range suites {
+		totalTests += suite.Tests
+		totalFailures += suite.Failures
+		totalErrors += suite.Errors
+		totalSkipped += suite.Skipped
+
+		for _, tc := range suite.TestCases {
+			outcome := tc.Failure
+			label := "FAIL"
+			if outcome == nil {
+				outcome = tc.Error
+				label = "ERROR"
+			}
+			if outcome == nil {
+				continue
+			}
+			name := tc.Name
+			if tc.ClassName != "" {
+				name = tc.ClassName + "." + tc.Name
+			}
+			message := strings.TrimSpace(outcome.Message)
+			if message == "" {
+				message = strings.TrimSpace(firstLine(outcome.Text))
+			}
+			out = append(out, fmt.Sprintf("%s %s: %s", label, name, message))
+		}
+	}
+
+	out = append(out, fmt.Sprintf("tests=%d failures=%d errors=%d skipped=%d", totalTests, totalFailures, totalErrors, totalSkipped))
+	return strings.Join(out, "\n"), nil
+}
+
+func parseJUnitXML(raw string) ([]junitTestSuite, error) {
+	decoder := xml.NewDecoder(strings.NewReader(raw))
+
+	var wrapper junitTestSuites
+	if err := decoder.Decode(&wrapper); err == nil && len(wrapper.Suites) > 0 {
+		return wrapper.Suites, nil
+	}
+
+	var single junitTestSuite
+	if err := xml.Unmarshal([]byte(raw), &single); err != nil {
+		return nil, fmt.Errorf("parse junit xml: %w", err)
+	}
+	return []junitTestSuite{single}, nil
+}
+
+func firstLine(s string) string {
+	if idx := strings.IndexByte(s, '\n'); idx != -1 {
+		return s[:idx]
+	}
+	return s
+}
+
+var rspecSummaryLine = regexp.MustCompile(`^\d+ examples?, \d+ failures?`)
+var rspecProgressLine = regexp.MustCompile(`^[.FP*]+$`)
+
+func looksLikeRSpecOutput(raw string) bool {
+	for _, line := range strings.Split(raw, "\n") {
+		if rspecSummaryLine.MatchString(strings.TrimSpace(line)) {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterRSpecOutput reduces RSpec's default formatter output to its
+// failure listing and summary, dropping the dot-per-example progress
+// line that carries no information once a run has finished.
+func FilterRSpecOutput(raw string) string {
+	var out []string
+	for _, line := range strings.Split(raw, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+		if rspecProgressLine.MatchString(trimmed) {
+			continue
+		}
+		out = append(out, trimmed)
+	}
+	return strings.Join(out, "\n")
+}
+
+var cargoRunningLine = regexp.MustCompile(`^running \d+ tests?$`)
+var cargoResultLine = regexp.MustCompile(`^test result: `)
+var cargoPassLine = regexp.MustCompile(`^test .+ \.\.\. ok$`)
+
+func looksLikeCargoTestOutput(raw string) bool {
+	for _, line := range strings.Split(raw, "\n") {
+		if cargoRunningLine.MatchString(strings.TrimSpace(line)) {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterCargoTestOutput reduces `cargo test` output to its running-tests
+// headers, failures, and per-suite result summaries, dropping
+// individually passing test lines.
+func FilterCargoTestOutput(raw string) string {
+	var out []string
+	for _, line := range strings.Split(raw, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+		if cargoPassLine.MatchString(trimmed) {
+			continue
+		}
+		out = append(out, trimmed)
+	}
+	return strings.Join(out, "\n")
+}
@@ -0,0 +1,67 @@
+package filter
+
+import "testing"
+
+func TestFilterRSpecOutput(t *testing.T) {
+	raw := "...F.\n\nFailures:\n\n  1) Widget does a thing\n     Failure/Error: expect(1).to eq(2)\n\n5 examples, 1 failure"
+	out := FilterRSpecOutput(raw)
+
+	if out == raw {
+		t.Fatal("FilterRSpecOutput made no changes")
+	}
+	if containsLine(out, "...F.") {
+		t.Error("FilterRSpecOutput should drop the dot-progress line")
+	}
+	if !containsLine(out, "5 examples, 1 failure") {
+		t.Error("FilterRSpecOutput should keep the summary line")
+	}
+}
+
+func TestFilterCargoTestOutput(t *testing.T) {
+	raw := "running 2 tests\ntest tests::ok_case ... ok\ntest tests::bad_case ... FAILED\n\ntest result: FAILED. 1 passed; 1 failed; 0 ignored"
+	out := FilterCargoTestOutput(raw)
+
+	if containsLine(out, "test tests::ok_case ... ok") {
+		t.Error("FilterCargoTestOutput should drop passing test lines")
+	}
+	if !containsLine(out, "test tests::bad_case ... FAILED") {
+		t.Error("FilterCargoTestOutput should keep failing test lines")
+	}
+	if !containsLine(out, "test result: FAILED. 1 passed; 1 failed; 0 ignored") {
+		t.Error("FilterCargoTestOutput should keep the result summary")
+	}
+}
+
+func TestFilterTestOutputDetectsFrameworks(t *testing.T) {
+	rspec := "...\n\n3 examples, 0 failures"
+	if got := FilterTestOutput(rspec); got != FilterRSpecOutput(rspec) {
+		t.Errorf("FilterTestOutput(rspec) = %q, want FilterRSpecOutput output", got)
+	}
+
+	cargo := "running 1 test\ntest it_works ... ok\n\ntest result: ok. 1 passed; 0 failed"
+	if got := FilterTestOutput(cargo); got != FilterCargoTestOutput(cargo) {
+		t.Errorf("FilterTestOutput(cargo) = %q, want FilterCargoTestOutput output", got)
+	}
+}
+
+func containsLine(s, line string) bool {
+	for _, l := range splitLines(s) {
+		if l == line {
+			return true
+		}
+	}
+	return false
+}
+
+func splitLines(s string) []string {
+	var out []string
+	start := 0
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\n' {
+			out = append(out, s[start:i])
+			start = i + 1
+		}
+	}
+	out = append(out, s[start:])
+	return out
+}
@@ -0,0 +1,40 @@
+package filter
+
+import "testing"
+
+const sampleJUnitXML = `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="pkg" tests="3" failures="1" errors="0" skipped="1">
+    <testcase name="TestOK" classname="pkg"></testcase>
+    <testcase name="TestSkipped" classname="pkg"></testcase>
+    <testcase name="TestBroken" classname="pkg">
+      <failure message="assertion failed">expected 1, got 2
+at line 10</failure>
+    </testcase>
+  </testsuite>
+</testsuites>`
+
+func TestFilterJUnitXML(t *testing.T) {
+	out, err := FilterJUnitXML(sampleJUnitXML)
+	if err != nil {
+		t.Fatalf("FilterJUnitXML: %v", err)
+	}
+
+	want := "FAIL pkg.TestBroken: assertion failed\ntests=3 failures=1 errors=0 skipped=1"
+	if out != want {
+		t.Fatalf("FilterJUnitXML = %q, want %q", out, want)
+	}
+}
+
+func TestFilterTestOutputDetectsJUnitXML(t *testing.T) {
+	out := FilterTestOutput(sampleJUnitXML)
+	if out == sampleJUnitXML {
+		t.Fatal("FilterTestOutput did not recognize JUnit XML input")
+	}
+}
+
+func TestFilterJUnitXMLInvalid(t *testing.T) {
+	if _, err := FilterJUnitXML("not xml at all"); err == nil {
+		t.Fatal("FilterJUnitXML should error on non-XML input")
+	}
+}
@@ -0,0 +1,23 @@
+package filter
+
+import "fmt"
+
+// defaultMaxOutputBytes caps filtered output size when no config value
+// overrides it, keeping a single command's output from blowing the
+// context budget on its own.
+const defaultMaxOutputBytes = 200_000
+
+// truncateToBudget keeps the head and tail of s and replaces the middle
+// with a marker noting how many bytes were omitted, if s exceeds max
+// bytes. A max of 0 or less disables truncation.
+func truncateToBudget(s string, max int) string {
+	if max <= 0 || len(s) <= max {
+		return s
+	}
+
+	half := max / 2
+	head := s[:half]
+	tail := s[len(s)-half:]
+	omitted := len(s) - len(head) - len(tail)
+	return fmt.Sprintf("%s\n... [truncated %d bytes] ...\n%s", head, omitted, tail)
+}
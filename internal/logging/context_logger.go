@@ -0,0 +1,83 @@
+package logging
+
+import "fmt"
+
+// ContextLogger wraps a Logger with a set of fields that are attached to
+// every entry it logs, so a caller that's already inside a request or
+// session doesn't have to repeat its identifying fields (tool, sessionId,
+// ...) on every log call.
+type ContextLogger struct {
+	base   *Logger
+	fields map[string]interface{}
+}
+
+// NewContextLogger creates a ContextLogger with no fields of its own,
+// delegating every entry to base.
+func NewContextLogger(base *Logger) *ContextLogger {
+	return &ContextLogger{base: base}
+}
+
+// WithFields returns a new ContextLogger whose fields are cl's fields
+// merged with fields, with fields taking precedence on conflicting keys.
+// cl itself is left unchanged, so a shared base ContextLogger can be
+// specialized per call site without the specializations affecting
+// each other.
+func (cl *ContextLogger) WithFields(fields map[string]interface{}) *ContextLogger {
+	return &ContextLogger{base: cl.base, fields: mergeFields(cl.fields, fields)}
+}
+
+// mergeFields returns a new map containing base's entries overlaid with
+// override's, so override wins on any shared key.
+func mergeFields(base, override map[string]interface{}) map[string]interface{} {
+	if len(base) == 0 && len(override) == 0 {
+		return nil
+	}
+	merged := make(map[string]interface{}, len(base)+len(override))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range override {
+		merged[k] = v
+	}
+	return merged
+}
+
+// Debug logs msg at LevelDebug, merging fields with cl's stored fields.
+func (cl *ContextLogger) Debug(msg string, fields map[string]interface{}) {
+	cl.base.Debug(msg, mergeFields(cl.fields, fields))
+}
+
+// Info logs msg at LevelInfo, merging fields with cl's stored fields.
+func (cl *ContextLogger) Info(msg string, fields map[string]interface{}) {
+	cl.base.Info(msg, mergeFields(cl.fields, fields))
+}
+
+// Warn logs msg at LevelWarn, merging fields with cl's stored fields.
+func (cl *ContextLogger) Warn(msg string, fields map[string]interface{}) {
+	cl.base.Warn(msg, mergeFields(cl.fields, fields))
+}
+
+// Error logs msg at LevelError, merging fields with cl's stored fields.
+func (cl *ContextLogger) Error(msg string, fields map[string]interface{}) {
+	cl.base.Error(msg, mergeFields(cl.fields, fields))
+}
+
+// Debugf formats msg and logs it at LevelDebug with cl's stored fields.
+func (cl *ContextLogger) Debugf(format string, args ...interface{}) {
+	cl.base.Debug(fmt.Sprintf(format, args...), cl.fields)
+}
+
+// Infof formats msg and logs it at LevelInfo with cl's stored fields.
+func (cl *ContextLogger) Infof(format string, args ...interface{}) {
+	cl.base.Info(fmt.Sprintf(format, args...), cl.fields)
+}
+
+// Warnf formats msg and logs it at LevelWarn with cl's stored fields.
+func (cl *ContextLogger) Warnf(format string, args ...interface{}) {
+	cl.base.Warn(fmt.Sprintf(format, args...), cl.fields)
+}
+
+// Errorf formats msg and logs it at LevelError with cl's stored fields.
+func (cl *ContextLogger) Errorf(format string, args ...interface{}) {
+	cl.base.Error(fmt.Sprintf(format, args...), cl.fields)
+}
@@ -0,0 +1,77 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestContextLoggerMergesFields(t *testing.T) {
+	var buf bytes.Buffer
+	base := NewLogger(&buf, LevelDebug)
+	cl := NewContextLogger(base).WithFields(map[string]interface{}{"tool": "compose_up", "sessionId": "sess-1"})
+
+	cl.Info("started", map[string]interface{}{"service": "web"})
+
+	var e entry
+	if err := json.Unmarshal(buf.Bytes(), &e); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if e.Fields["tool"] != "compose_up" || e.Fields["sessionId"] != "sess-1" || e.Fields["service"] != "web" {
+		t.Fatalf("fields = %+v, want tool, sessionId, and service all present", e.Fields)
+	}
+}
+
+func TestContextLoggerWithFieldsOverridesOnConflict(t *testing.T) {
+	var buf bytes.Buffer
+	base := NewLogger(&buf, LevelDebug)
+	cl := NewContextLogger(base).WithFields(map[string]interface{}{"tool": "compose_up"})
+	specialized := cl.WithFields(map[string]interface{}{"tool": "compose_down"})
+
+	specialized.Info("done", nil)
+
+	var e entry
+	if err := json.Unmarshal(buf.Bytes(), &e); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if e.Fields["tool"] != "compose_down" {
+		t.Fatalf("fields[tool] = %v, want compose_down to win over the parent's compose_up", e.Fields["tool"])
+	}
+}
+
+func TestContextLoggerWithFieldsDoesNotMutateParent(t *testing.T) {
+	var buf bytes.Buffer
+	base := NewLogger(&buf, LevelDebug)
+	parent := NewContextLogger(base).WithFields(map[string]interface{}{"tool": "compose_up"})
+	_ = parent.WithFields(map[string]interface{}{"extra": "value"})
+
+	buf.Reset()
+	parent.Info("unaffected", nil)
+
+	var e entry
+	if err := json.Unmarshal(buf.Bytes(), &e); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if _, ok := e.Fields["extra"]; ok {
+		t.Fatal("parent's fields were mutated by a child WithFields call")
+	}
+}
+
+func TestContextLoggerFormattedVariants(t *testing.T) {
+	var buf bytes.Buffer
+	base := NewLogger(&buf, LevelDebug)
+	cl := NewContextLogger(base).WithFields(map[string]interface{}{"tool": "compose_logs"})
+
+	cl.Errorf("failed after %d retries", 3)
+
+	var e entry
+	if err := json.Unmarshal(buf.Bytes(), &e); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if e.Msg != "failed after 3 retries" {
+		t.Fatalf("Msg = %q, want formatted message", e.Msg)
+	}
+	if e.Fields["tool"] != "compose_logs" {
+		t.Fatalf("fields[tool] = %v, want compose_logs", e.Fields["tool"])
+	}
+}
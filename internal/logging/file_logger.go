@@ -0,0 +1,120 @@
+// Package logging provides the server's diagnostic logging, separate
+// from the MCP protocol's own stdout stream so log output never corrupts
+// a JSON-RPC response.
+package logging
+
+import (
+	"fmt"
+	"os"
+)
+
+const (
+	// DefaultMaxSize is the log file size, in bytes, at which FileLogger
+	// rotates by default.
+	DefaultMaxSize int64 = 10 * 1024 * 1024
+	// DefaultMaxBackups is the number of rotated backups FileLogger
+	// keeps by default.
+	DefaultMaxBackups = 3
+)
+
+// FileLogger writes log lines to a file, rotating it once it grows past
+// MaxSize. Rotated files are renamed "<path>.1", "<path>.2", and so on,
+// up to MaxBackups; older backups are discarded.
+type FileLogger struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+
+	file *os.File
+	size int64
+}
+
+// NewFileLogger opens path for appending, creating it if necessary, and
+// returns a FileLogger that rotates it once it exceeds maxSize bytes,
+// keeping up to maxBackups rotated copies. A maxSize of 0 uses
+// DefaultMaxSize; a negative maxBackups uses DefaultMaxBackups.
+func NewFileLogger(path string, maxSize int64, maxBackups int) (*FileLogger, error) {
+	if maxSize <= 0 {
+		maxSize = DefaultMaxSize
+	}
+	if maxBackups < 0 {
+		maxBackups = DefaultMaxBackups
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("open log file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("stat log file: %w", err)
+	}
+
+	return &FileLogger{
+		path:       path,
+		maxSize:    maxSize,
+		maxBackups: maxBackups,
+		file:       file,
+		size:       info.Size(),
+	}, nil
+}
+
+// Write appends p to the log file, rotating first if it would push the
+// file past maxSize.
+func (l *FileLogger) Write(p []byte) (int, error) {
+	if l.size+int64(len(p)) > l.maxSize {
+		if err := l.rotate(); err != nil {
+			return 0, fmt.Errorf("rotate log file: %w", err)
+		}
+	}
+
+	n, err := l.file.Write(p)
+	l.size += int64(n)
+	return n, err
+}
+
+// rotate closes the current log file, shifts existing backups up by one
+// generation (dropping anything past maxBackups), renames the current
+// file to "<path>.1", and reopens a fresh file at path.
+func (l *FileLogger) rotate() error {
+	if err := l.file.Close(); err != nil {
+		return err
+	}
+
+	for gen := l.maxBackups; gen >= 1; gen-- {
+		src := backupPath(l.path, gen)
+		if gen == l.maxBackups {
+			os.Remove(src)
+			continue
+		}
+		dst := backupPath(l.path, gen+1)
+		if _, err := os.Stat(src); err == nil {
+			os.Rename(src, dst)
+		}
+	}
+	if l.maxBackups > 0 {
+		os.Rename(l.path, backupPath(l.path, 1))
+	} else {
+		os.Remove(l.path)
+	}
+
+	file, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	l.file = file
+	l.size = 0
+	return nil
+}
+
+// backupPath returns the rotated log path for the given generation,
+// e.g. backupPath("server.log", 1) is "server.log.1".
+func backupPath(path string, gen int) string {
+	return fmt.Sprintf("%s.%d", path, gen)
+}
+
+// Close closes the underlying log file.
+func (l *FileLogger) Close() error {
+	return l.file.Close()
+}
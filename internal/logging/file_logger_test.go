@@ -0,0 +1,76 @@
+package logging
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileLoggerRotatesPastMaxSize(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "server.log")
+
+	l, err := NewFileLogger(path, 10, 2)
+	if err != nil {
+		t.Fatalf("NewFileLogger: %v", err)
+	}
+	defer l.Close()
+
+	for i := 0; i < 3; i++ {
+		if _, err := l.Write([]byte("0123456789")); err != nil {
+			t.Fatalf("Write %d: %v", i, err)
+		}
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("current log file missing: %v", err)
+	}
+	if _, err := os.Stat(path + ".1"); err != nil {
+		t.Errorf("backup .1 missing: %v", err)
+	}
+	if _, err := os.Stat(path + ".2"); err != nil {
+		t.Errorf("backup .2 missing: %v", err)
+	}
+}
+
+func TestFileLoggerDiscardsBackupsPastMaxBackups(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "server.log")
+
+	l, err := NewFileLogger(path, 5, 1)
+	if err != nil {
+		t.Fatalf("NewFileLogger: %v", err)
+	}
+	defer l.Close()
+
+	for i := 0; i < 3; i++ {
+		if _, err := l.Write([]byte("abcdef")); err != nil {
+			t.Fatalf("Write %d: %v", i, err)
+		}
+	}
+
+	if _, err := os.Stat(path + ".1"); err != nil {
+		t.Errorf("backup .1 missing: %v", err)
+	}
+	if _, err := os.Stat(path + ".2"); !os.IsNotExist(err) {
+		t.Errorf("backup .2 should not exist when maxBackups is 1, err=%v", err)
+	}
+}
+
+func TestNewFileLoggerDefaults(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "server.log")
+
+	l, err := NewFileLogger(path, 0, -1)
+	if err != nil {
+		t.Fatalf("NewFileLogger: %v", err)
+	}
+	defer l.Close()
+
+	if l.maxSize != DefaultMaxSize {
+		t.Errorf("maxSize = %d, want %d", l.maxSize, DefaultMaxSize)
+	}
+	if l.maxBackups != DefaultMaxBackups {
+		t.Errorf("maxBackups = %d, want %d", l.maxBackups, DefaultMaxBackups)
+	}
+}
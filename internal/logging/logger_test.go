@@ -0,0 +1,69 @@
+package logging
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"sync"
+	"testing"
+)
+
+func TestLoggerFiltersBelowLevel(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(&buf, LevelWarn)
+
+	l.Debug("ignored", nil)
+	l.Info("ignored", nil)
+	l.Warn("kept", nil)
+	l.Error("also kept", nil)
+
+	lines := countLines(t, &buf)
+	if lines != 2 {
+		t.Fatalf("lines written = %d, want 2", lines)
+	}
+}
+
+func TestLoggerConcurrentWritesDoNotInterleave(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(&buf, LevelDebug)
+
+	const goroutines = 20
+	const perGoroutine = 50
+
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+	for g := 0; g < goroutines; g++ {
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perGoroutine; i++ {
+				l.Info("concurrent", map[string]interface{}{"i": i})
+			}
+		}()
+	}
+	wg.Wait()
+
+	scanner := bufio.NewScanner(bytes.NewReader(buf.Bytes()))
+	count := 0
+	for scanner.Scan() {
+		var e entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			t.Fatalf("line %d is not valid JSON: %v (%q)", count, err, scanner.Text())
+		}
+		count++
+	}
+	if count != goroutines*perGoroutine {
+		t.Fatalf("lines written = %d, want %d", count, goroutines*perGoroutine)
+	}
+}
+
+func countLines(t *testing.T, buf *bytes.Buffer) int {
+	t.Helper()
+	scanner := bufio.NewScanner(bytes.NewReader(buf.Bytes()))
+	n := 0
+	for scanner.Scan() {
+		if scanner.Text() != "" {
+			n++
+		}
+	}
+	return n
+}
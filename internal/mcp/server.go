@@ -0,0 +1,96 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Handler executes a tool call and returns its result.
+type Handler func(ctx context.Context, args json.RawMessage) (*CallToolResult, error)
+
+// Tool pairs a tool definition with the handler that implements it.
+type Tool struct {
+	Definition ToolDefinition
+	Handler    Handler
+}
+
+// Server dispatches JSON-RPC 2.0 requests to registered tools.
+type Server struct {
+	tools map[string]Tool
+}
+
+// NewServer creates an empty Server ready for tool registration.
+func NewServer() *Server {
+	return &Server{tools: make(map[string]Tool)}
+}
+
+// Register adds a tool to the server, replacing any existing tool with the
+// same name.
+func (s *Server) Register(tool Tool) {
+	s.tools[tool.Definition.Name] = tool
+}
+
+// Tools returns the definitions of all registered tools, used to answer
+// tools/list requests.
+func (s *Server) Tools() []ToolDefinition {
+	defs := make([]ToolDefinition, 0, len(s.tools))
+	for _, t := range s.tools {
+		defs = append(defs, t.Definition)
+	}
+	return defs
+}
+
+// callTool runs tool.Handler, converting a panic into an error that
+// names the offending tool instead of letting it unwind past Handle and
+// take down the whole server. A single misbehaving tool - including one
+// backed by a plugin hook - shouldn't be able to crash every other
+// in-flight request.
+func callTool(ctx context.Context, tool Tool, args json.RawMessage) (result *CallToolResult, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("tool %s panicked: %v", tool.Definition.Name, r)
+		}
+	}()
+	return tool.Handler(ctx, args)
+}
+
+type callToolParams struct {
+	Name      string          `json:"name"`
+	Arguments json.RawMessage `json:"arguments"`
+}
+
+// Handle processes a single JSON-RPC request and returns the response to
+// write back to the client.
+func (s *Server) Handle(ctx context.Context, req *Request) *Response {
+	resp := &Response{JSONRPC: "2.0", ID: req.ID}
+
+	switch req.Method {
+	case "tools/list":
+		resp.Result = map[string]interface{}{"tools": s.Tools()}
+	case "tools/call":
+		var params callToolParams
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			resp.Error = &Error{Code: InvalidParams, Message: fmt.Sprintf("invalid params: %v", err)}
+			return resp
+		}
+		tool, ok := s.tools[params.Name]
+		if !ok {
+			resp.Error = &Error{Code: MethodNotFound, Message: fmt.Sprintf("unknown tool: %s", params.Name)}
+			return resp
+		}
+		if err := validateParams(tool.Definition.InputSchema, params.Arguments); err != nil {
+			resp.Error = &Error{Code: InvalidParams, Message: err.Error()}
+			return resp
+		}
+		result, err := callTool(ctx, tool, params.Arguments)
+		if err != nil {
+			resp.Error = &Error{Code: InternalError, Message: err.Error()}
+			return resp
+		}
+		resp.Result = result
+	default:
+		resp.Error = &Error{Code: MethodNotFound, Message: fmt.Sprintf("unknown method: %s", req.Method)}
+	}
+	return resp
+}
@@ -0,0 +1,61 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestCallToolRecoversPanic(t *testing.T) {
+	tool := Tool{
+		Definition: ToolDefinition{Name: "compose_up"},
+		Handler: func(ctx context.Context, args json.RawMessage) (*CallToolResult, error) {
+			panic("boom")
+		},
+	}
+
+	result, err := callTool(context.Background(), tool, nil)
+	if result != nil {
+		t.Fatalf("result = %+v, want nil", result)
+	}
+	if err == nil {
+		t.Fatal("err = nil, want an error naming the panicking tool")
+	}
+	if got := err.Error(); got != "tool compose_up panicked: boom" {
+		t.Fatalf("err = %q, want it to name the tool and the panic value", got)
+	}
+}
+
+func TestHandleToolsCallSurvivesHandlerPanic(t *testing.T) {
+	s := NewServer()
+	s.Register(Tool{
+		Definition: ToolDefinition{Name: "compose_up"},
+		Handler: func(ctx context.Context, args json.RawMessage) (*CallToolResult, error) {
+			panic("boom")
+		},
+	})
+
+	params, _ := json.Marshal(callToolParams{Name: "compose_up"})
+	req := &Request{JSONRPC: "2.0", Method: "tools/call", Params: params}
+
+	resp := s.Handle(context.Background(), req)
+	if resp.Error == nil {
+		t.Fatal("resp.Error = nil, want an error response instead of a crash")
+	}
+	if resp.Error.Code != InternalError {
+		t.Fatalf("resp.Error.Code = %d, want %d", resp.Error.Code, InternalError)
+	}
+
+	// The server itself must still be usable after a handler panics.
+	s.Register(Tool{
+		Definition: ToolDefinition{Name: "compose_ps"},
+		Handler: func(ctx context.Context, args json.RawMessage) (*CallToolResult, error) {
+			return TextResult("ok"), nil
+		},
+	})
+	params2, _ := json.Marshal(callToolParams{Name: "compose_ps"})
+	resp2 := s.Handle(context.Background(), &Request{JSONRPC: "2.0", Method: "tools/call", Params: params2})
+	if resp2.Error != nil {
+		t.Fatalf("resp2.Error = %+v, want nil after a healthy call following the panic", resp2.Error)
+	}
+}
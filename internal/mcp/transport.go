@@ -0,0 +1,37 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+)
+
+// ServeStdio reads newline-delimited JSON-RPC requests from r, dispatches
+// them to the server, and writes newline-delimited responses to w. It
+// returns when r is exhausted or returns an error other than io.EOF.
+func (s *Server) ServeStdio(ctx context.Context, r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	enc := json.NewEncoder(w)
+
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var req Request
+		if err := json.Unmarshal(line, &req); err != nil {
+			enc.Encode(&Response{JSONRPC: "2.0", Error: &Error{Code: ParseError, Message: err.Error()}})
+			continue
+		}
+		resp := s.Handle(ctx, &req)
+		if err := enc.Encode(resp); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil && err != io.EOF {
+		return err
+	}
+	return nil
+}
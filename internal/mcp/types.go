@@ -0,0 +1,72 @@
+// Package mcp implements the Model Context Protocol JSON-RPC 2.0 surface
+// used by the server: request/response envelopes, tool definitions, and
+// the content types returned from tool calls.
+package mcp
+
+import "encoding/json"
+
+// Request is a single JSON-RPC 2.0 request received over stdio.
+type Request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// Response is a single JSON-RPC 2.0 response written to stdout.
+type Response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+}
+
+// Error is a JSON-RPC 2.0 error object.
+type Error struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+// Standard JSON-RPC 2.0 error codes.
+const (
+	ParseError     = -32700
+	InvalidRequest = -32600
+	MethodNotFound = -32601
+	InvalidParams  = -32602
+	InternalError  = -32603
+)
+
+// ToolDefinition describes a tool the server exposes to MCP clients.
+// InputSchema is passed through to clients verbatim as a raw JSON
+// Schema document, so nested `properties`, `items`, `enum`, `default`,
+// `description`, and `required` are preserved exactly as each
+// controller wrote them — there is no separate conversion step that
+// could drop them.
+type ToolDefinition struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	InputSchema json.RawMessage `json:"inputSchema"`
+}
+
+// Content is a single piece of content in a CallToolResult.
+type Content struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// CallToolResult is the result returned from a tools/call request.
+type CallToolResult struct {
+	Content []Content `json:"content"`
+	IsError bool      `json:"isError,omitempty"`
+}
+
+// TextResult is a convenience constructor for a single-text-block result.
+func TextResult(text string) *CallToolResult {
+	return &CallToolResult{Content: []Content{{Type: "text", Text: text}}}
+}
+
+// ErrorResult is a convenience constructor for a single-text-block error result.
+func ErrorResult(text string) *CallToolResult {
+	return &CallToolResult{Content: []Content{{Type: "text", Text: text}}, IsError: true}
+}
@@ -0,0 +1,67 @@
+package mcp
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// TestToolDefinitionSchemaRoundTrips verifies that a deeply nested input
+// schema survives a ToolDefinition marshal/unmarshal cycle byte-for-byte,
+// since InputSchema is a raw JSON document with no lossy conversion step
+// in between.
+func TestToolDefinitionSchemaRoundTrips(t *testing.T) {
+	schema := json.RawMessage(`{
+		"type": "object",
+		"properties": {
+			"host": {
+				"type": "object",
+				"description": "remote host configuration",
+				"properties": {
+					"tls": {
+						"type": "object",
+						"properties": {
+							"certPaths": {
+								"type": "array",
+								"items": {"type": "string"},
+								"default": []
+							}
+						},
+						"required": ["certPaths"]
+					},
+					"auth": {
+						"type": "string",
+						"enum": ["key", "password"]
+					}
+				},
+				"required": ["tls"]
+			}
+		},
+		"required": ["host"]
+	}`)
+
+	def := ToolDefinition{Name: "docker_host_add", Description: "add a remote host", InputSchema: schema}
+
+	data, err := json.Marshal(def)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	var decoded ToolDefinition
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	var want, got interface{}
+	if err := json.Unmarshal(schema, &want); err != nil {
+		t.Fatalf("unmarshal want schema: %v", err)
+	}
+	if err := json.Unmarshal(decoded.InputSchema, &got); err != nil {
+		t.Fatalf("unmarshal got schema: %v", err)
+	}
+
+	wantJSON, _ := json.Marshal(want)
+	gotJSON, _ := json.Marshal(got)
+	if string(wantJSON) != string(gotJSON) {
+		t.Fatalf("InputSchema after round-trip = %s, want %s", gotJSON, wantJSON)
+	}
+}
@@ -0,0 +1,74 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// schemaProperty is the subset of a JSON Schema property object the
+// dispatch layer checks before calling a tool's Handler.
+type schemaProperty struct {
+	Enum []json.RawMessage `json:"enum"`
+}
+
+// toolSchema is the subset of a tool's InputSchema the dispatch layer
+// validates arguments against: which top-level fields are required, and
+// which top-level fields are constrained to an enum.
+type toolSchema struct {
+	Properties map[string]schemaProperty `json:"properties"`
+	Required   []string                  `json:"required"`
+}
+
+// validateParams checks args against schema's declared required fields
+// and enum constraints, returning a descriptive error for the first
+// violation found. A schema that doesn't parse as a toolSchema is
+// treated as having no constraints, rather than blocking every call to
+// that tool.
+func validateParams(schema, args json.RawMessage) error {
+	var s toolSchema
+	if err := json.Unmarshal(schema, &s); err != nil {
+		return nil
+	}
+
+	var fields map[string]json.RawMessage
+	if len(args) > 0 {
+		if err := json.Unmarshal(args, &fields); err != nil {
+			return fmt.Errorf("arguments must be a JSON object: %w", err)
+		}
+	}
+
+	for _, name := range s.Required {
+		if _, ok := fields[name]; !ok {
+			return fmt.Errorf("missing required field %q", name)
+		}
+	}
+
+	for name, prop := range s.Properties {
+		if len(prop.Enum) == 0 {
+			continue
+		}
+		value, ok := fields[name]
+		if !ok {
+			continue
+		}
+		if !enumContains(prop.Enum, value) {
+			return fmt.Errorf("field %q: value not in allowed enum", name)
+		}
+	}
+
+	return nil
+}
+
+// enumContains reports whether value matches one of enum's raw JSON
+// values, comparing on their normalized text since schema enums mix
+// strings, numbers, and booleans.
+func enumContains(enum []json.RawMessage, value json.RawMessage) bool {
+	normalized := strings.TrimSpace(string(value))
+	for _, e := range enum {
+		if strings.TrimSpace(string(e)) == normalized {
+			return true
+		}
+	}
+	return false
+}
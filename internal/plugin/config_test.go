@@ -0,0 +1,89 @@
+package plugin
+
+import "testing"
+
+func TestEffectiveConfigNoMatchingEnvironment(t *testing.T) {
+	cfg := Config{Name: "notifier", Enabled: true, Settings: map[string]interface{}{"level": "info"}}
+
+	if got := cfg.EffectiveConfig(""); got.Enabled != true || got.Settings["level"] != "info" {
+		t.Fatalf("EffectiveConfig(\"\") = %+v, want cfg unchanged", got)
+	}
+	if got := cfg.EffectiveConfig("staging"); got.Enabled != true || got.Settings["level"] != "info" {
+		t.Fatalf("EffectiveConfig(staging) = %+v, want cfg unchanged with no matching override", got)
+	}
+}
+
+func TestEffectiveConfigOverridesEnabled(t *testing.T) {
+	disabled := false
+	cfg := Config{
+		Name:    "notifier",
+		Enabled: true,
+		Environments: map[string]EnvironmentOverride{
+			"production": {Enabled: &disabled},
+		},
+	}
+
+	got := cfg.EffectiveConfig("production")
+	if got.Enabled {
+		t.Fatal("EffectiveConfig(production).Enabled = true, want false from the override")
+	}
+	if cfg.Enabled != true {
+		t.Fatal("base cfg.Enabled was mutated by EffectiveConfig")
+	}
+}
+
+func TestEffectiveConfigDeepMergesSettings(t *testing.T) {
+	cfg := Config{
+		Name: "notifier",
+		Settings: map[string]interface{}{
+			"level":   "info",
+			"retries": float64(3),
+			"webhook": map[string]interface{}{
+				"url":     "https://dev.example.com/hook",
+				"timeout": float64(5),
+			},
+		},
+		Environments: map[string]EnvironmentOverride{
+			"development": {
+				Settings: map[string]interface{}{
+					"level": "debug",
+					"webhook": map[string]interface{}{
+						"url": "https://localhost/hook",
+					},
+				},
+			},
+		},
+	}
+
+	got := cfg.EffectiveConfig("development")
+	if got.Settings["level"] != "debug" {
+		t.Fatalf("Settings[level] = %v, want debug", got.Settings["level"])
+	}
+	if got.Settings["retries"] != float64(3) {
+		t.Fatalf("Settings[retries] = %v, want the base value of 3 to survive an untouched key", got.Settings["retries"])
+	}
+	webhook, ok := got.Settings["webhook"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("Settings[webhook] = %v, want a nested map", got.Settings["webhook"])
+	}
+	if webhook["url"] != "https://localhost/hook" {
+		t.Fatalf("webhook[url] = %v, want the override URL", webhook["url"])
+	}
+	if webhook["timeout"] != float64(5) {
+		t.Fatalf("webhook[timeout] = %v, want the base timeout to survive the nested merge", webhook["timeout"])
+	}
+
+	if cfg.Settings["level"] != "info" {
+		t.Fatal("base cfg.Settings was mutated by EffectiveConfig")
+	}
+}
+
+func TestDeepMergeSettingsEmptyInputs(t *testing.T) {
+	base := map[string]interface{}{"a": 1}
+	if got := deepMergeSettings(base, nil); got["a"] != 1 {
+		t.Fatalf("deepMergeSettings(base, nil) = %v, want base unchanged", got)
+	}
+	if got := deepMergeSettings(nil, base); got["a"] != 1 {
+		t.Fatalf("deepMergeSettings(nil, override) = %v, want override returned as-is", got)
+	}
+}
@@ -0,0 +1,31 @@
+package plugin
+
+import "testing"
+
+func TestValidateGitURL(t *testing.T) {
+	cases := []struct {
+		name    string
+		url     string
+		wantErr bool
+	}{
+		{name: "https", url: "https://github.com/example/plugin.git", wantErr: false},
+		{name: "ssh scheme", url: "ssh://git@github.com/example/plugin.git", wantErr: false},
+		{name: "scp style", url: "git@github.com:example/plugin.git", wantErr: false},
+		{name: "flag injection via upload-pack", url: "--upload-pack=touch$IFS/tmp/pwned;true", wantErr: true},
+		{name: "bare dash", url: "-oProxyCommand=evil", wantErr: true},
+		{name: "scheme with leading dash host", url: "ssh://-oProxyCommand=evil", wantErr: true},
+		{name: "empty", url: "", wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateGitURL(tc.url)
+			if tc.wantErr && err == nil {
+				t.Fatalf("validateGitURL(%q) = nil, want error", tc.url)
+			}
+			if !tc.wantErr && err != nil {
+				t.Fatalf("validateGitURL(%q) = %v, want nil", tc.url, err)
+			}
+		})
+	}
+}
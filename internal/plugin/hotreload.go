@@ -0,0 +1,153 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// defaultHotReloadInterval is both the poll period and the debounce
+// window: a changed file is only reloaded once it has looked identical
+// across two consecutive polls, so a plugin binary still being written
+// doesn't get loaded half-finished.
+//
+// This polls mtimes rather than using an OS file-watching API
+// (inotify/kqueue via fsnotify) because the project takes no
+// dependencies beyond the standard library. fileSnapshot and the
+// known/lastSeen comparison below are the only state StartHotReload
+// needs, so swapping in an event-driven watcher later is a matter of
+// feeding reloadChangedFile/handleRemovedFile from that source instead
+// of a ticker, without touching Manager's public API.
+const defaultHotReloadInterval = 2 * time.Second
+
+// fileSnapshot is the subset of file metadata hot reload compares across
+// polls to decide whether a plugin binary has changed.
+type fileSnapshot struct {
+	modTime time.Time
+	size    int64
+}
+
+// StartHotReload watches the manager's plugin search paths for added,
+// changed, or removed .so files and reloads only the affected plugin,
+// until ctx is cancelled. It returns immediately; the watch runs in a
+// background goroutine. Pass interval <= 0 to use
+// defaultHotReloadInterval.
+func (m *Manager) StartHotReload(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = defaultHotReloadInterval
+	}
+	// Snapshotting here, before the goroutine is even scheduled, avoids a
+	// race where a file added or removed between this call returning and
+	// the goroutine actually running would silently never be detected -
+	// it would already be (or already not be) in the baseline.
+	known := m.snapshotPluginFiles()
+	go m.watchLoop(ctx, interval, known)
+}
+
+func (m *Manager) watchLoop(ctx context.Context, interval time.Duration, known map[string]fileSnapshot) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	lastSeen := known
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			current := m.snapshotPluginFiles()
+
+			for path, info := range current {
+				if prev, ok := known[path]; ok && prev == info {
+					continue // unchanged since it was last (re)loaded
+				}
+				if seen, ok := lastSeen[path]; ok && seen == info {
+					// Identical across two consecutive polls: stable.
+					m.reloadChangedFile(path)
+					known[path] = info
+				}
+			}
+			for path := range known {
+				if _, ok := current[path]; ok {
+					continue
+				}
+				if _, stillSeen := lastSeen[path]; !stillSeen {
+					// Gone for two consecutive polls: treat as removed,
+					// rather than reacting to a transient delete+rewrite.
+					m.handleRemovedFile(path)
+					delete(known, path)
+				}
+			}
+
+			lastSeen = current
+		}
+	}
+}
+
+// snapshotPluginFiles stats every .so file directly inside the manager's
+// search paths, keyed by absolute path.
+func (m *Manager) snapshotPluginFiles() map[string]fileSnapshot {
+	m.mu.RLock()
+	paths := append([]string(nil), m.searchPaths...)
+	m.mu.RUnlock()
+
+	snapshot := make(map[string]fileSnapshot)
+	for _, dir := range paths {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if entry.IsDir() || filepath.Ext(entry.Name()) != ".so" {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name())
+			info, err := entry.Info()
+			if err != nil {
+				continue
+			}
+			snapshot[path] = fileSnapshot{modTime: info.ModTime(), size: info.Size()}
+		}
+	}
+	return snapshot
+}
+
+// reloadChangedFile unloads the plugin at path if it was already loaded,
+// then loads the file fresh, logging rather than failing the watch loop
+// on error.
+func (m *Manager) reloadChangedFile(path string) {
+	name := strings.TrimSuffix(filepath.Base(path), ".so")
+
+	m.mu.RLock()
+	_, wasLoaded := m.loaded[name]
+	m.mu.RUnlock()
+
+	if wasLoaded {
+		if err := m.Unload(name); err != nil {
+			fmt.Fprintf(pluginLog, "plugin %s: hot reload: unload: %v\n", name, err)
+			return
+		}
+	}
+	if err := m.Load(path); err != nil {
+		fmt.Fprintf(pluginLog, "plugin %s: hot reload: load: %v\n", name, err)
+	}
+}
+
+// handleRemovedFile unloads the plugin at path if it's currently loaded,
+// since its backing binary no longer exists on disk.
+func (m *Manager) handleRemovedFile(path string) {
+	name := strings.TrimSuffix(filepath.Base(path), ".so")
+
+	m.mu.RLock()
+	_, loaded := m.loaded[name]
+	m.mu.RUnlock()
+	if !loaded {
+		return
+	}
+	if err := m.Unload(name); err != nil {
+		fmt.Fprintf(pluginLog, "plugin %s: hot reload: unload removed file: %v\n", name, err)
+	}
+}
@@ -0,0 +1,104 @@
+package plugin
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSnapshotPluginFilesDetectsSoFiles(t *testing.T) {
+	dir := t.TempDir()
+	writePluginFile(t, filepath.Join(dir, "greeter.so"), "fake")
+	writePluginFile(t, filepath.Join(dir, "greeter.json"), "{}")
+	if err := os.Mkdir(filepath.Join(dir, "subdir.so"), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	m := NewManager([]string{dir})
+	snapshot := m.snapshotPluginFiles()
+
+	if len(snapshot) != 1 {
+		t.Fatalf("snapshot = %+v, want exactly one .so file", snapshot)
+	}
+	if _, ok := snapshot[filepath.Join(dir, "greeter.so")]; !ok {
+		t.Fatalf("snapshot missing greeter.so: %+v", snapshot)
+	}
+}
+
+func TestHandleRemovedFileUnloadsLoadedPlugin(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "greeter.so")
+
+	m := NewManager([]string{dir})
+	m.loaded["greeter"] = &loaded{
+		info: Info{Name: "greeter", Version: "1.0.0"},
+		impl: &fakePlugin{info: Info{Name: "greeter", Version: "1.0.0"}},
+		path: path,
+	}
+
+	m.handleRemovedFile(path)
+
+	if _, ok := m.loaded["greeter"]; ok {
+		t.Fatal("greeter still in m.loaded after handleRemovedFile")
+	}
+}
+
+func TestHandleRemovedFileNoopWhenNotLoaded(t *testing.T) {
+	m := NewManager([]string{t.TempDir()})
+	// Must not panic or error when the path was never loaded.
+	m.handleRemovedFile(filepath.Join(t.TempDir(), "ghost.so"))
+}
+
+func TestWatchLoopDebouncesBeforeUnloadingRemovedFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "greeter.so")
+	writePluginFile(t, path, "fake")
+
+	m := NewManager([]string{dir})
+	m.loaded["greeter"] = &loaded{
+		info: Info{Name: "greeter", Version: "1.0.0"},
+		impl: &fakePlugin{info: Info{Name: "greeter", Version: "1.0.0"}},
+		path: path,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	interval := 20 * time.Millisecond
+	m.StartHotReload(ctx, interval)
+
+	if err := os.Remove(path); err != nil {
+		t.Fatalf("remove plugin file: %v", err)
+	}
+
+	// Removal is only acted on once the file has been absent across two
+	// consecutive polls, so immediately after removal the plugin must
+	// still be loaded.
+	time.Sleep(interval / 2)
+	m.mu.RLock()
+	_, stillLoaded := m.loaded["greeter"]
+	m.mu.RUnlock()
+	if !stillLoaded {
+		t.Fatal("greeter was unloaded before the debounce window elapsed")
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		m.mu.RLock()
+		_, ok := m.loaded["greeter"]
+		m.mu.RUnlock()
+		if !ok {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("greeter was never unloaded after the debounce window")
+}
+
+func writePluginFile(t *testing.T, path, contents string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
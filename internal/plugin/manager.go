@@ -0,0 +1,534 @@
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"plugin"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// stderr is where plugin handler errors are reported, since a single
+// misbehaving plugin shouldn't interrupt the compose command that fired
+// the event.
+var pluginLog = os.Stderr
+
+// defaultHookTimeout bounds how long FireEvent waits for a single
+// plugin's HandleEvent before counting it as slow and moving on.
+const defaultHookTimeout = 10 * time.Second
+
+// HookStats counts how often a plugin's event hook has run into trouble,
+// for server_health to factor into its report.
+type HookStats struct {
+	// Slow counts calls that exceeded the hook timeout. The call itself
+	// isn't forcibly stopped, since Plugin.HandleEvent takes no context
+	// to cancel - it's logged and counted, not killed.
+	Slow int64 `json:"slow"`
+	// Failed counts calls that returned an error or panicked.
+	Failed int64 `json:"failed"`
+}
+
+// loaded tracks one plugin that has been installed and opened.
+type loaded struct {
+	info   Info
+	impl   Plugin
+	path   string
+	config Config
+}
+
+// Manager discovers, installs, and loads plugins from a set of search
+// directories, in order. The first directory is preferred for new
+// installs; the rest exist so operators can also drop plugins in a
+// read-only, shared location.
+type Manager struct {
+	mu                sync.RWMutex
+	searchPaths       []string
+	validator         *Validator
+	loaded            map[string]*loaded
+	gitInstallAllowed bool
+	hookTimeout       time.Duration
+	environment       string
+
+	statsMu sync.Mutex
+	stats   map[string]*HookStats
+}
+
+// NewManager creates a Manager that installs into and discovers plugins
+// from searchPaths, in order. Installing from a git source is disabled
+// by default; enable it with EnableGitInstall since it runs the Go
+// toolchain against cloned, untrusted code.
+func NewManager(searchPaths []string) *Manager {
+	return &Manager{
+		searchPaths: searchPaths,
+		validator:   NewValidator(),
+		loaded:      make(map[string]*loaded),
+		hookTimeout: defaultHookTimeout,
+		stats:       make(map[string]*HookStats),
+	}
+}
+
+// SetEnvironment names the deployment environment (e.g. "development",
+// "production") used to resolve each plugin's Config.Environments
+// override during Initialize. Empty (the default) applies no override.
+func (m *Manager) SetEnvironment(environment string) {
+	m.mu.Lock()
+	m.environment = environment
+	m.mu.Unlock()
+}
+
+// SetHookTimeout overrides how long FireEvent waits for a single
+// plugin's HandleEvent before counting it as slow and moving on.
+func (m *Manager) SetHookTimeout(d time.Duration) {
+	m.mu.Lock()
+	m.hookTimeout = d
+	m.mu.Unlock()
+}
+
+// HookStats returns a snapshot of slow/failed hook counts per plugin
+// name, for server_health to report alongside the plugin list.
+func (m *Manager) HookStats() map[string]HookStats {
+	m.statsMu.Lock()
+	defer m.statsMu.Unlock()
+
+	out := make(map[string]HookStats, len(m.stats))
+	for name, s := range m.stats {
+		out[name] = *s
+	}
+	return out
+}
+
+func (m *Manager) recordHookSlow(name string) {
+	m.statsMu.Lock()
+	defer m.statsMu.Unlock()
+	m.statFor(name).Slow++
+}
+
+func (m *Manager) recordHookFailure(name string) {
+	m.statsMu.Lock()
+	defer m.statsMu.Unlock()
+	m.statFor(name).Failed++
+}
+
+// statFor returns name's HookStats, creating it if needed. Callers must
+// hold statsMu.
+func (m *Manager) statFor(name string) *HookStats {
+	s, ok := m.stats[name]
+	if !ok {
+		s = &HookStats{}
+		m.stats[name] = s
+	}
+	return s
+}
+
+// EnableGitInstall turns on (or off) installing plugins from a git
+// repository URL.
+func (m *Manager) EnableGitInstall(enabled bool) {
+	m.mu.Lock()
+	m.gitInstallAllowed = enabled
+	m.mu.Unlock()
+}
+
+// Install adds a plugin from source, detects its scheme, and loads it.
+// Only local filesystem paths are supported so far; other schemes
+// return a clear error so their implementations can slot in without
+// changing this dispatch.
+func (m *Manager) Install(source string) error {
+	switch {
+	case strings.HasPrefix(source, "git+"), strings.HasSuffix(source, ".git"):
+		return m.installFromGit(source)
+	case strings.Contains(source, "://") && !strings.HasPrefix(source, "file://"):
+		return fmt.Errorf("install %s: unsupported source scheme", source)
+	default:
+		return m.installFromPath(strings.TrimPrefix(source, "file://"))
+	}
+}
+
+// installFromGit shallow-clones a git plugin source to a temp directory,
+// builds it as a Go plugin, and installs the resulting .so through
+// installFromPath. It requires EnableGitInstall, since it runs the Go
+// toolchain against cloned, untrusted code.
+func (m *Manager) installFromGit(source string) error {
+	m.mu.RLock()
+	allowed := m.gitInstallAllowed
+	m.mu.RUnlock()
+	if !allowed {
+		return fmt.Errorf("install %s: git plugin installs are disabled (enable with EnableGitInstall)", source)
+	}
+
+	url := strings.TrimPrefix(source, "git+")
+	if err := validateGitURL(url); err != nil {
+		return fmt.Errorf("install %s: %w", source, err)
+	}
+
+	tmpDir, err := os.MkdirTemp("", "plugin-git-*")
+	if err != nil {
+		return fmt.Errorf("install %s: create temp dir: %w", source, err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	// "--" stops git from treating url as a flag, since a crafted value
+	// like "--upload-pack=..." would otherwise be interpreted as one
+	// despite validateGitURL's scheme check.
+	clone := exec.Command("git", "clone", "--depth", "1", "--", url, tmpDir)
+	if out, err := clone.CombinedOutput(); err != nil {
+		return fmt.Errorf("install %s: git clone failed: %w: %s", source, err, strings.TrimSpace(string(out)))
+	}
+
+	name := strings.TrimSuffix(filepath.Base(url), ".git")
+	soPath := filepath.Join(tmpDir, name+".so")
+	build := exec.Command("go", "build", "-buildmode=plugin", "-o", soPath, ".")
+	build.Dir = tmpDir
+	if out, err := build.CombinedOutput(); err != nil {
+		return fmt.Errorf("install %s: go build failed: %w: %s", source, err, strings.TrimSpace(string(out)))
+	}
+
+	return m.installFromPathAs(soPath, source)
+}
+
+// gitURLPattern matches the remote forms git accepts that can't be
+// mistaken for a command-line flag: an explicit scheme
+// (https://, git://, ssh://, git@host), or classic scp-style
+// "user@host:path".
+var gitURLPattern = regexp.MustCompile(`^(https?|git|ssh)://[^\s-][^\s]*$|^[\w.-]+@[\w.-]+:[^\s]+$`)
+
+// validateGitURL rejects a git remote that doesn't look like a genuine
+// URL or scp-style remote, since passing an attacker-controlled string
+// straight to `git clone` lets anything beginning with "-" be
+// interpreted as a flag (e.g. "--upload-pack=...") rather than a
+// repository, a well-known argument-injection path to local command
+// execution.
+func validateGitURL(url string) error {
+	if !gitURLPattern.MatchString(url) {
+		return fmt.Errorf("source %q does not look like a git URL", url)
+	}
+	return nil
+}
+
+// installFromPath validates the local .so at path, copies it into the
+// first writable search path, writes a default enabled Config next to
+// it, and loads it.
+func (m *Manager) installFromPath(path string) error {
+	return m.installFromPathAs(path, path)
+}
+
+// installFromPathAs is installFromPath with an explicit source label,
+// used by installFromGit so the recorded Config.Source is the original
+// git URL rather than a temp build path that won't exist afterward.
+func (m *Manager) installFromPathAs(path, source string) error {
+	if err := m.validator.ValidateFile(path); err != nil {
+		return fmt.Errorf("install: %w", err)
+	}
+
+	destDir, err := m.firstWritableSearchPath()
+	if err != nil {
+		return fmt.Errorf("install: %w", err)
+	}
+
+	name := strings.TrimSuffix(filepath.Base(path), ".so")
+	destPath := filepath.Join(destDir, filepath.Base(path))
+	if err := copyFile(path, destPath); err != nil {
+		return fmt.Errorf("install %s: %w", name, err)
+	}
+
+	cfg := Config{Name: name, Enabled: true, Source: source}
+	if err := writeConfig(destDir, cfg); err != nil {
+		return fmt.Errorf("install %s: %w", name, err)
+	}
+
+	if err := m.Load(destPath); err != nil {
+		return fmt.Errorf("install %s: %w", name, err)
+	}
+	return nil
+}
+
+// Load opens the plugin binary at path and registers it under its
+// reported name.
+func (m *Manager) Load(path string) error {
+	p, err := plugin.Open(path)
+	if err != nil {
+		return fmt.Errorf("load %s: %w", path, err)
+	}
+
+	sym, err := p.Lookup("Plugin")
+	if err != nil {
+		return fmt.Errorf("load %s: %w", path, err)
+	}
+	impl, ok := sym.(Plugin)
+	if !ok {
+		return fmt.Errorf("load %s: exported Plugin symbol does not implement plugin.Plugin", path)
+	}
+
+	info := impl.Info()
+	if err := checkMinVersion(info.MinVersion); err != nil {
+		return fmt.Errorf("load %s: %w", path, err)
+	}
+
+	m.mu.RLock()
+	for _, dep := range info.Dependencies {
+		if err := m.validator.validateDependency(dep, m.loaded); err != nil {
+			m.mu.RUnlock()
+			return fmt.Errorf("load %s: %w", path, err)
+		}
+	}
+	m.mu.RUnlock()
+
+	if err := impl.Init(); err != nil {
+		return fmt.Errorf("load %s: init: %w", path, err)
+	}
+
+	m.mu.Lock()
+	m.loaded[info.Name] = &loaded{info: info, impl: impl, path: path}
+	m.mu.Unlock()
+	return nil
+}
+
+// Unload calls the named plugin's Shutdown and removes it from the
+// registry, but leaves its files on disk; use Uninstall to also delete
+// them.
+func (m *Manager) Unload(name string) error {
+	m.mu.Lock()
+	p, ok := m.loaded[name]
+	if ok {
+		delete(m.loaded, name)
+	}
+	m.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("unload %s: not loaded", name)
+	}
+	if err := p.impl.Shutdown(); err != nil {
+		return fmt.Errorf("unload %s: %w", name, err)
+	}
+	return nil
+}
+
+// Uninstall unloads the named plugin and deletes its binary and config
+// from disk. The binary's path is re-validated against the configured
+// search paths before deletion, so an Uninstall call can never remove a
+// file outside a directory this manager is allowed to manage.
+func (m *Manager) Uninstall(name string) error {
+	m.mu.Lock()
+	p, ok := m.loaded[name]
+	m.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("uninstall %s: not loaded", name)
+	}
+
+	path := p.path
+	dir, err := m.searchPathContaining(path)
+	if err != nil {
+		return fmt.Errorf("uninstall %s: %w", name, err)
+	}
+
+	if err := m.Unload(name); err != nil {
+		return fmt.Errorf("uninstall %s: %w", name, err)
+	}
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("uninstall %s: remove binary: %w", name, err)
+	}
+	configPath := filepath.Join(dir, name+".json")
+	if err := os.Remove(configPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("uninstall %s: remove config: %w", name, err)
+	}
+	return nil
+}
+
+// searchPathContaining returns the configured search path that is the
+// parent directory of path, or an error if path falls outside all of
+// them. This guards Uninstall against ever deleting a file a plugin's
+// loaded() path was somehow pointed outside the manager's own
+// directories.
+func (m *Manager) searchPathContaining(path string) (string, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", fmt.Errorf("resolve %s: %w", path, err)
+	}
+	for _, dir := range m.searchPaths {
+		absDir, err := filepath.Abs(dir)
+		if err != nil {
+			continue
+		}
+		rel, err := filepath.Rel(absDir, absPath)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			continue
+		}
+		return absDir, nil
+	}
+	return "", fmt.Errorf("%s is outside all configured plugin search paths", path)
+}
+
+// Initialize discovers and loads every enabled plugin already installed
+// in the manager's search paths, so plugins installed by a previous run
+// come back up after a server restart.
+func (m *Manager) Initialize() error {
+	m.mu.RLock()
+	environment := m.environment
+	m.mu.RUnlock()
+
+	for _, dir := range m.searchPaths {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+				continue
+			}
+			data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+			if err != nil {
+				continue
+			}
+			var cfg Config
+			if err := json.Unmarshal(data, &cfg); err != nil {
+				continue
+			}
+			cfg = cfg.EffectiveConfig(environment)
+			if !cfg.Enabled {
+				continue
+			}
+			soPath := filepath.Join(dir, cfg.Name+".so")
+			if _, err := os.Stat(soPath); err != nil {
+				continue
+			}
+			if err := m.Load(soPath); err != nil {
+				fmt.Fprintf(pluginLog, "plugin %s: %v\n", cfg.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// List returns the Info of every currently loaded plugin.
+func (m *Manager) List() []Info {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	infos := make([]Info, 0, len(m.loaded))
+	for _, p := range m.loaded {
+		infos = append(infos, p.info)
+	}
+	return infos
+}
+
+// Shutdown calls Shutdown on every loaded plugin, logging rather than
+// failing on an individual plugin's error so the rest still get a
+// chance to clean up during server shutdown.
+func (m *Manager) Shutdown() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for name, p := range m.loaded {
+		if err := p.impl.Shutdown(); err != nil {
+			fmt.Fprintf(pluginLog, "plugin %s: shutdown: %v\n", name, err)
+		}
+	}
+	m.loaded = make(map[string]*loaded)
+}
+
+// FireEvent delivers event to every loaded plugin that registered
+// interest in its type. A handler error, panic, or timeout is logged and
+// does not stop delivery to the remaining plugins, since events are
+// notifications, not a pipeline the caller depends on.
+func (m *Manager) FireEvent(event Event) {
+	m.mu.RLock()
+	timeout := m.hookTimeout
+	plugins := make(map[string]*loaded, len(m.loaded))
+	for name, p := range m.loaded {
+		plugins[name] = p
+	}
+	m.mu.RUnlock()
+
+	for name, p := range plugins {
+		if !wantsEvent(p.info.Events, event.Type) {
+			continue
+		}
+		m.runHook(name, p.impl, event, timeout)
+	}
+}
+
+// runHook calls impl.HandleEvent(event), recovering a panic into an
+// error and giving up after timeout. HandleEvent takes no context, so a
+// hook that exceeds timeout is counted as slow and logged, but its
+// goroutine is left to finish on its own rather than forcibly killed.
+func (m *Manager) runHook(name string, impl Plugin, event Event, timeout time.Duration) {
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- fmt.Errorf("panic: %v", r)
+			}
+		}()
+		done <- impl.HandleEvent(event)
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			m.recordHookFailure(name)
+			fmt.Fprintf(pluginLog, "plugin %s: handle %s event: %v\n", name, event.Type, err)
+		}
+	case <-time.After(timeout):
+		m.recordHookSlow(name)
+		fmt.Fprintf(pluginLog, "plugin %s: handle %s event: exceeded %s timeout\n", name, event.Type, timeout)
+	}
+}
+
+func wantsEvent(events []EventType, t EventType) bool {
+	for _, e := range events {
+		if e == t {
+			return true
+		}
+	}
+	return false
+}
+
+// firstWritableSearchPath returns the first directory in searchPaths
+// that exists (creating it if necessary) and accepts a test write.
+func (m *Manager) firstWritableSearchPath() (string, error) {
+	var tried []string
+	for _, dir := range m.searchPaths {
+		tried = append(tried, dir)
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			continue
+		}
+		probe := filepath.Join(dir, ".write-test")
+		if err := os.WriteFile(probe, nil, 0o644); err != nil {
+			continue
+		}
+		os.Remove(probe)
+		return dir, nil
+	}
+	return "", fmt.Errorf("no writable plugin search path among %v", tried)
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o755)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+func writeConfig(dir string, cfg Config) error {
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal config: %w", err)
+	}
+	return os.WriteFile(filepath.Join(dir, cfg.Name+".json"), data, 0o644)
+}
@@ -0,0 +1,114 @@
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+type fakePlugin struct {
+	info     Info
+	shutdown error
+
+	mu     sync.Mutex
+	events []Event
+}
+
+func (p *fakePlugin) Info() Info  { return p.info }
+func (p *fakePlugin) Init() error { return nil }
+func (p *fakePlugin) HandleEvent(event Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, event)
+	return nil
+}
+func (p *fakePlugin) Shutdown() error { return p.shutdown }
+
+func (p *fakePlugin) received() []Event {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]Event(nil), p.events...)
+}
+
+func TestManagerUninstallRemovesBinaryAndConfig(t *testing.T) {
+	dir := t.TempDir()
+	soPath := filepath.Join(dir, "greeter.so")
+	configPath := filepath.Join(dir, "greeter.json")
+	if err := os.WriteFile(soPath, []byte("fake"), 0o644); err != nil {
+		t.Fatalf("write fake .so: %v", err)
+	}
+	if err := os.WriteFile(configPath, []byte("{}"), 0o644); err != nil {
+		t.Fatalf("write fake config: %v", err)
+	}
+
+	m := NewManager([]string{dir})
+	m.loaded["greeter"] = &loaded{
+		info: Info{Name: "greeter", Version: "1.0.0"},
+		impl: &fakePlugin{info: Info{Name: "greeter", Version: "1.0.0"}},
+		path: soPath,
+	}
+
+	if err := m.Uninstall("greeter"); err != nil {
+		t.Fatalf("Uninstall: %v", err)
+	}
+
+	if _, err := os.Stat(soPath); !os.IsNotExist(err) {
+		t.Errorf("soPath still exists after Uninstall: err=%v", err)
+	}
+	if _, err := os.Stat(configPath); !os.IsNotExist(err) {
+		t.Errorf("configPath still exists after Uninstall: err=%v", err)
+	}
+	if _, ok := m.loaded["greeter"]; ok {
+		t.Error("greeter still present in m.loaded after Uninstall")
+	}
+}
+
+func TestManagerUninstallNotLoaded(t *testing.T) {
+	m := NewManager([]string{t.TempDir()})
+	if err := m.Uninstall("ghost"); err == nil {
+		t.Fatal("Uninstall of a plugin that was never loaded should fail")
+	}
+}
+
+func TestManagerUninstallRejectsPathOutsideSearchPaths(t *testing.T) {
+	outside := t.TempDir()
+	soPath := filepath.Join(outside, "rogue.so")
+	if err := os.WriteFile(soPath, []byte("fake"), 0o644); err != nil {
+		t.Fatalf("write fake .so: %v", err)
+	}
+
+	m := NewManager([]string{t.TempDir()})
+	m.loaded["rogue"] = &loaded{
+		info: Info{Name: "rogue", Version: "1.0.0"},
+		impl: &fakePlugin{info: Info{Name: "rogue", Version: "1.0.0"}},
+		path: soPath,
+	}
+
+	if err := m.Uninstall("rogue"); err == nil {
+		t.Fatal("Uninstall should refuse a path outside the manager's search paths")
+	}
+	if _, err := os.Stat(soPath); err != nil {
+		t.Errorf("soPath should be untouched after a rejected Uninstall, stat err=%v", err)
+	}
+}
+
+func TestManagerFireEventDeliversOnlyToInterestedPlugins(t *testing.T) {
+	m := NewManager(nil)
+
+	interested := &fakePlugin{info: Info{Name: "notifier", Events: []EventType{EventWorkspaceChange}}}
+	uninterested := &fakePlugin{info: Info{Name: "builder", Events: []EventType{EventDockerHostChange}}}
+	m.loaded["notifier"] = &loaded{info: interested.info, impl: interested}
+	m.loaded["builder"] = &loaded{info: uninterested.info, impl: uninterested}
+
+	event := Event{Type: EventWorkspaceChange, Data: map[string]interface{}{"name": "myapp", "path": "/srv/myapp"}}
+	m.FireEvent(event)
+
+	got := interested.received()
+	if len(got) != 1 || got[0].Type != EventWorkspaceChange {
+		t.Fatalf("interested plugin received = %+v, want one EventWorkspaceChange", got)
+	}
+	if len(uninterested.received()) != 0 {
+		t.Fatalf("uninterested plugin received = %+v, want none", uninterested.received())
+	}
+}
@@ -0,0 +1,149 @@
+// Package plugin loads and manages optional server extensions: compiled
+// Go plugins (.so) that observe or react to compose command execution.
+// Plugins are discovered from a set of search directories and loaded
+// with the standard library's plugin.Open, so they must be built with
+// `go build -buildmode=plugin` against the same Go toolchain and module
+// versions as the server.
+package plugin
+
+// Plugin is the interface every loadable plugin must export as a
+// package-level variable named "Plugin" for plugin.Lookup to find.
+type Plugin interface {
+	// Info describes the plugin for compatibility checks and reporting.
+	Info() Info
+	// Init is called once after the plugin is loaded, before it
+	// receives any events.
+	Init() error
+	// HandleEvent is called for every event the plugin has registered
+	// interest in via Info().Events.
+	HandleEvent(event Event) error
+	// Shutdown is called before the plugin is unloaded.
+	Shutdown() error
+}
+
+// Info describes a plugin's identity and compatibility requirements.
+type Info struct {
+	Name         string       `json:"name"`
+	Version      string       `json:"version"`
+	MinVersion   string       `json:"minVersion"`
+	Description  string       `json:"description"`
+	Events       []EventType  `json:"events"`
+	Dependencies []Dependency `json:"dependencies,omitempty"`
+}
+
+// DependencyType identifies what kind of resource a Dependency refers
+// to.
+type DependencyType string
+
+const (
+	// DependencyBinary requires an executable to be present on PATH.
+	DependencyBinary DependencyType = "binary"
+	// DependencyPlugin requires another plugin to already be loaded.
+	DependencyPlugin DependencyType = "plugin"
+)
+
+// Dependency is one requirement a plugin declares, checked before it is
+// allowed to load.
+type Dependency struct {
+	Type    DependencyType `json:"type"`
+	Name    string         `json:"name"`
+	Version string         `json:"version,omitempty"` // constraint, e.g. "^1.2.0"
+}
+
+// EventType identifies a kind of event a plugin can react to.
+type EventType string
+
+const (
+	EventPreCommand   EventType = "pre_command"
+	EventPostCommand  EventType = "post_command"
+	EventServiceStart EventType = "service_start"
+	EventServiceStop  EventType = "service_stop"
+	EventError        EventType = "error"
+	// EventDockerHostChange fires when the active Docker context
+	// compose commands run against changes, so plugins that keep
+	// per-host state (e.g. monitoring) can reset it.
+	EventDockerHostChange EventType = "docker_host_change"
+	// EventWorkspaceChange fires when the working directory compose
+	// commands run against changes, so plugins that keep per-workspace
+	// state can reset or re-sync it.
+	EventWorkspaceChange EventType = "workspace_change"
+)
+
+// Event carries the data for one fired EventType.
+type Event struct {
+	Type EventType              `json:"type"`
+	Data map[string]interface{} `json:"data,omitempty"`
+}
+
+// Config is the on-disk, per-plugin configuration written alongside an
+// installed plugin's binary as "<name>.json".
+type Config struct {
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+	Source  string `json:"source,omitempty"`
+	// Settings are arbitrary plugin-defined options, passed through
+	// untouched today but reserved for a future Plugin.Init(Config)
+	// once plugins need to read them.
+	Settings map[string]interface{} `json:"settings,omitempty"`
+	// Environments overrides Enabled/Settings per deployment environment
+	// (e.g. "development", "production"), applied by EffectiveConfig.
+	Environments map[string]EnvironmentOverride `json:"environments,omitempty"`
+}
+
+// EnvironmentOverride is one environment's override of a plugin's base
+// Config, applied by Config.EffectiveConfig.
+type EnvironmentOverride struct {
+	// Enabled, if set, replaces the base Config's Enabled for this
+	// environment.
+	Enabled *bool `json:"enabled,omitempty"`
+	// Settings is deep-merged into the base Config's Settings: a key
+	// present here wins, a nested map is merged key-by-key rather than
+	// replacing the whole map, and anything the override doesn't
+	// mention is left as the base value.
+	Settings map[string]interface{} `json:"settings,omitempty"`
+}
+
+// EffectiveConfig applies environment's override (if c.Environments has
+// one) on top of c, returning a new Config that leaves c unmodified. An
+// empty environment, or one with no matching entry, returns c as-is.
+func (c Config) EffectiveConfig(environment string) Config {
+	override, ok := c.Environments[environment]
+	if environment == "" || !ok {
+		return c
+	}
+
+	effective := c
+	if override.Enabled != nil {
+		effective.Enabled = *override.Enabled
+	}
+	effective.Settings = deepMergeSettings(c.Settings, override.Settings)
+	return effective
+}
+
+// deepMergeSettings merges override onto base, recursing into nested
+// map[string]interface{} values instead of replacing them wholesale, so
+// an override only needs to mention the keys it actually changes.
+func deepMergeSettings(base, override map[string]interface{}) map[string]interface{} {
+	if len(base) == 0 {
+		return override
+	}
+	if len(override) == 0 {
+		return base
+	}
+
+	merged := make(map[string]interface{}, len(base)+len(override))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, overrideVal := range override {
+		baseVal, exists := merged[k]
+		baseMap, baseIsMap := baseVal.(map[string]interface{})
+		overrideMap, overrideIsMap := overrideVal.(map[string]interface{})
+		if exists && baseIsMap && overrideIsMap {
+			merged[k] = deepMergeSettings(baseMap, overrideMap)
+		} else {
+			merged[k] = overrideVal
+		}
+	}
+	return merged
+}
@@ -0,0 +1,119 @@
+package plugin
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Validator checks that a candidate plugin binary is safe to load before
+// the manager ever calls plugin.Open on it.
+type Validator struct{}
+
+// NewValidator creates a Validator.
+func NewValidator() *Validator {
+	return &Validator{}
+}
+
+// validateDependency checks dep against the set of already-loaded
+// plugins. Binary dependencies are left to the caller, since checking
+// PATH doesn't require knowledge of other plugins.
+func (v *Validator) validateDependency(dep Dependency, loaded map[string]*loaded) error {
+	if dep.Type == DependencyBinary {
+		return v.checkBinaryExists(dep.Name, dep.Version)
+	}
+	if dep.Type != DependencyPlugin {
+		return nil
+	}
+
+	other, ok := loaded[dep.Name]
+	if !ok {
+		return fmt.Errorf("requires plugin %q, which is not loaded", dep.Name)
+	}
+	if dep.Version == "" {
+		return nil
+	}
+
+	ok, err := satisfiesConstraint(other.info.Version, dep.Version)
+	if err != nil {
+		return fmt.Errorf("dependency %q: %w", dep.Name, err)
+	}
+	if !ok {
+		return fmt.Errorf("requires plugin %q %s, found %s", dep.Name, dep.Version, other.info.Version)
+	}
+	return nil
+}
+
+// versionOutput extracts the first "X.Y" or "X.Y.Z"-shaped token from a
+// binary's "--version" output, which is the common format tools like
+// `docker --version` or `git --version` use.
+var versionOutput = func(s string) string {
+	for _, field := range strings.Fields(s) {
+		field = strings.TrimLeft(field, "v")
+		field = strings.TrimRight(field, ",")
+		if isValidVersion(field) {
+			return field
+		}
+	}
+	return ""
+}
+
+// checkBinaryExists looks up name on the real PATH via exec.LookPath. If
+// constraint is non-empty, it also runs "<name> --version" and checks
+// the reported version satisfies it; a binary that doesn't support
+// --version or whose output can't be parsed is treated as satisfying
+// an unparseable constraint rather than failing validation outright,
+// since not every tool prints a machine-readable version string.
+func (v *Validator) checkBinaryExists(name, constraint string) error {
+	path, err := exec.LookPath(name)
+	if err != nil {
+		return fmt.Errorf("requires binary %q, not found on PATH (%s)", name, os.Getenv("PATH"))
+	}
+
+	if constraint == "" {
+		return nil
+	}
+
+	out, err := exec.Command(path, "--version").CombinedOutput()
+	if err != nil {
+		return nil
+	}
+	version := versionOutput(string(out))
+	if version == "" {
+		return nil
+	}
+
+	ok, err := satisfiesConstraint(version, constraint)
+	if err != nil || ok {
+		return nil
+	}
+	return fmt.Errorf("requires binary %q %s, found %s", name, constraint, version)
+}
+
+// ValidateFile checks that path points to a readable, regular ".so"
+// file. It does not open the plugin, since that would execute its
+// package init functions; deeper checks (MinVersion, dependencies)
+// happen after Info() is available.
+func (v *Validator) ValidateFile(path string) error {
+	if filepath.Ext(path) != ".so" {
+		return fmt.Errorf("plugin %s: not a .so file", path)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("plugin %s: %w", path, err)
+	}
+	if !info.Mode().IsRegular() {
+		return fmt.Errorf("plugin %s: not a regular file", path)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("plugin %s: not readable: %w", path, err)
+	}
+	f.Close()
+
+	return nil
+}
@@ -0,0 +1,162 @@
+package plugin
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// HostVersion is the current application version, compared against each
+// plugin's Info.MinVersion during loading.
+const HostVersion = "1.0.0"
+
+// semver holds a parsed "X.Y.Z[-prerelease][+build]" version. Build
+// metadata is accepted but ignored for comparison, per semver rules.
+type semver struct {
+	major, minor, patch int
+	prerelease          string
+}
+
+// checkMinVersion returns an error if minVersion is non-empty and
+// greater than HostVersion, so a plugin built against a newer host
+// can't load and misbehave against an older one.
+func checkMinVersion(minVersion string) error {
+	if minVersion == "" {
+		return nil
+	}
+
+	min, err := parseVersion(minVersion)
+	if err != nil {
+		return fmt.Errorf("invalid minVersion %q: %w", minVersion, err)
+	}
+	host, err := parseVersion(HostVersion)
+	if err != nil {
+		return fmt.Errorf("invalid host version %q: %w", HostVersion, err)
+	}
+
+	if compareVersions(host, min) < 0 {
+		return fmt.Errorf("requires host version >= %s, running %s", minVersion, HostVersion)
+	}
+	return nil
+}
+
+// isValidVersion reports whether v is a parseable "X.Y[.Z]" version,
+// optionally followed by a "-prerelease" and/or "+build" suffix (e.g.
+// "1.2", "1.2.0", "1.2.0-rc1", "1.2.0+build5").
+func isValidVersion(v string) bool {
+	_, err := parseVersion(v)
+	return err == nil
+}
+
+// parseVersion parses a plain version string (no leading constraint
+// operator) into its numeric components plus an optional prerelease
+// tag. Missing minor/patch components default to zero.
+func parseVersion(v string) (semver, error) {
+	var out semver
+
+	if v == "" {
+		return out, fmt.Errorf("empty version")
+	}
+
+	if idx := strings.Index(v, "+"); idx != -1 {
+		v = v[:idx]
+	}
+	if idx := strings.Index(v, "-"); idx != -1 {
+		out.prerelease = v[idx+1:]
+		v = v[:idx]
+	}
+
+	parts := strings.SplitN(v, ".", 3)
+	if parts[0] == "" {
+		return out, fmt.Errorf("empty version")
+	}
+	nums := [3]int{}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return out, fmt.Errorf("non-numeric component %q", part)
+		}
+		nums[i] = n
+	}
+	out.major, out.minor, out.patch = nums[0], nums[1], nums[2]
+	return out, nil
+}
+
+// compareVersions returns -1, 0, or 1 as a is less than, equal to, or
+// greater than b. A version with no prerelease outranks the same
+// major.minor.patch with one, per semver precedence rules; beyond that,
+// prereleases compare lexically, which is enough to order the simple
+// "rc1", "rc2", "beta" style tags plugins actually use.
+func compareVersions(a, b semver) int {
+	if a.major != b.major {
+		return cmpInt(a.major, b.major)
+	}
+	if a.minor != b.minor {
+		return cmpInt(a.minor, b.minor)
+	}
+	if a.patch != b.patch {
+		return cmpInt(a.patch, b.patch)
+	}
+	switch {
+	case a.prerelease == b.prerelease:
+		return 0
+	case a.prerelease == "":
+		return 1
+	case b.prerelease == "":
+		return -1
+	default:
+		return strings.Compare(a.prerelease, b.prerelease)
+	}
+}
+
+func cmpInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// satisfiesConstraint reports whether version satisfies constraint,
+// which may be an exact version ("1.2.0"), or prefixed with "^"
+// (compatible within the same major version), "~" (compatible within
+// the same major.minor), or ">=" (at least).
+func satisfiesConstraint(version, constraint string) (bool, error) {
+	v, err := parseVersion(version)
+	if err != nil {
+		return false, fmt.Errorf("invalid version %q: %w", version, err)
+	}
+
+	switch {
+	case strings.HasPrefix(constraint, "^"):
+		base, err := parseVersion(constraint[1:])
+		if err != nil {
+			return false, fmt.Errorf("invalid constraint %q: %w", constraint, err)
+		}
+		return v.major == base.major && compareVersions(v, base) >= 0, nil
+
+	case strings.HasPrefix(constraint, "~"):
+		base, err := parseVersion(constraint[1:])
+		if err != nil {
+			return false, fmt.Errorf("invalid constraint %q: %w", constraint, err)
+		}
+		return v.major == base.major && v.minor == base.minor && compareVersions(v, base) >= 0, nil
+
+	case strings.HasPrefix(constraint, ">="):
+		base, err := parseVersion(strings.TrimSpace(constraint[2:]))
+		if err != nil {
+			return false, fmt.Errorf("invalid constraint %q: %w", constraint, err)
+		}
+		return compareVersions(v, base) >= 0, nil
+
+	default:
+		base, err := parseVersion(constraint)
+		if err != nil {
+			return false, fmt.Errorf("invalid constraint %q: %w", constraint, err)
+		}
+		return compareVersions(v, base) == 0, nil
+	}
+}
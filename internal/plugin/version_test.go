@@ -0,0 +1,43 @@
+package plugin
+
+import "testing"
+
+func TestCheckMinVersion(t *testing.T) {
+	cases := []struct {
+		name       string
+		minVersion string
+		wantErr    bool
+	}{
+		{name: "empty is always allowed", minVersion: "", wantErr: false},
+		{name: "below host version", minVersion: "0.9.0", wantErr: false},
+		{name: "equal to host version", minVersion: HostVersion, wantErr: false},
+		{name: "above host version", minVersion: "99.0.0", wantErr: true},
+		{name: "unparsable version", minVersion: "not-a-version", wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkMinVersion(tc.minVersion)
+			if tc.wantErr && err == nil {
+				t.Fatalf("checkMinVersion(%q) = nil, want error", tc.minVersion)
+			}
+			if !tc.wantErr && err != nil {
+				t.Fatalf("checkMinVersion(%q) = %v, want nil", tc.minVersion, err)
+			}
+		})
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	v1, _ := parseVersion("1.2.3")
+	v2, _ := parseVersion("1.2.4")
+	if compareVersions(v1, v2) >= 0 {
+		t.Errorf("compareVersions(1.2.3, 1.2.4) should be negative")
+	}
+
+	release, _ := parseVersion("1.0.0")
+	prerelease, _ := parseVersion("1.0.0-rc1")
+	if compareVersions(release, prerelease) <= 0 {
+		t.Error("a release should outrank the same version with a prerelease tag")
+	}
+}
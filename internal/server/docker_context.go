@@ -0,0 +1,208 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jontolof/docker-compose-mcp/internal/dockerhost"
+	"github.com/jontolof/docker-compose-mcp/internal/mcp"
+)
+
+// DockerContextTestParams are the arguments to the docker_context_test
+// tool.
+type DockerContextTestParams struct {
+	Name string `json:"name"`
+}
+
+// DockerHostSetActiveParams are the arguments to the
+// docker_host_set_active tool.
+type DockerHostSetActiveParams struct {
+	Name string `json:"name"`
+}
+
+// DockerHostAddParams are the arguments to the docker_host_add tool.
+type DockerHostAddParams struct {
+	Name         string `json:"name"`
+	Endpoint     string `json:"endpoint"`
+	KeyPath      string `json:"keyPath,omitempty"`
+	PasswordEnv  string `json:"passwordEnv,omitempty"`
+	PasswordFile string `json:"passwordFile,omitempty"`
+}
+
+// DockerHostRemoveParams are the arguments to the docker_host_remove
+// tool.
+type DockerHostRemoveParams struct {
+	Name string `json:"name"`
+}
+
+// DockerContextController exposes tools for discovering and checking
+// Docker contexts (hosts).
+type DockerContextController struct {
+	hosts *dockerhost.HostManager
+}
+
+// NewDockerContextController creates a DockerContextController backed
+// by hosts.
+func NewDockerContextController(hosts *dockerhost.HostManager) *DockerContextController {
+	return &DockerContextController{hosts: hosts}
+}
+
+// Tools returns the MCP tool definitions and handlers this controller
+// implements.
+func (c *DockerContextController) Tools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Definition: mcp.ToolDefinition{
+				Name:        "docker_context_list",
+				Description: "List the Docker contexts (hosts) available to the CLI.",
+				InputSchema: json.RawMessage(`{"type": "object", "properties": {}}`),
+			},
+			Handler: c.handleList,
+		},
+		{
+			Definition: mcp.ToolDefinition{
+				Name:        "docker_context_test",
+				Description: "Check whether the Docker daemon behind a named context responds.",
+				InputSchema: json.RawMessage(`{
+					"type": "object",
+					"properties": {
+						"name": {"type": "string"}
+					},
+					"required": ["name"]
+				}`),
+			},
+			Handler: c.handleTest,
+		},
+		{
+			Definition: mcp.ToolDefinition{
+				Name:        "docker_host_set_active",
+				Description: "Select the Docker context that compose commands should run against. An empty name restores the CLI's default context.",
+				InputSchema: json.RawMessage(`{
+					"type": "object",
+					"properties": {
+						"name": {"type": "string"}
+					}
+				}`),
+			},
+			Handler: c.handleSetActive,
+		},
+		{
+			Definition: mcp.ToolDefinition{
+				Name:        "docker_host_add",
+				Description: "Remember a remote Docker host (typically SSH-backed) so it survives a server restart. Use keyPath for authentication; passwords are never stored, only a reference to where one can be read from at connection time.",
+				InputSchema: json.RawMessage(`{
+					"type": "object",
+					"properties": {
+						"name": {"type": "string", "description": "Name to refer to this host by; matches the docker context name if one exists."},
+						"endpoint": {"type": "string", "description": "Docker endpoint, e.g. ssh://user@host. Must not embed a password."},
+						"keyPath": {"type": "string", "description": "Path to the SSH private key to authenticate with."},
+						"passwordEnv": {"type": "string", "description": "Name of an environment variable to read the password from at connection time, if this host needs password auth."},
+						"passwordFile": {"type": "string", "description": "Path to a file containing the password, read at connection time, if this host needs password auth."}
+					},
+					"required": ["name", "endpoint"]
+				}`),
+			},
+			Handler: c.handleAddHost,
+		},
+		{
+			Definition: mcp.ToolDefinition{
+				Name:        "docker_host_remove",
+				Description: "Forget a remote Docker host previously added with docker_host_add.",
+				InputSchema: json.RawMessage(`{
+					"type": "object",
+					"properties": {
+						"name": {"type": "string"}
+					},
+					"required": ["name"]
+				}`),
+			},
+			Handler: c.handleRemoveHost,
+		},
+		{
+			Definition: mcp.ToolDefinition{
+				Name:        "docker_host_list",
+				Description: "List manually configured remote Docker hosts (see docker_host_add). Use docker_context_list for hosts already registered with the Docker CLI.",
+				InputSchema: json.RawMessage(`{"type": "object", "properties": {}}`),
+			},
+			Handler: c.handleListHosts,
+		},
+	}
+}
+
+func (c *DockerContextController) handleList(ctx context.Context, args json.RawMessage) (*mcp.CallToolResult, error) {
+	if err := c.hosts.Refresh(ctx); err != nil {
+		return mcp.ErrorResult(err.Error()), nil
+	}
+
+	payload, err := json.Marshal(c.hosts.Contexts())
+	if err != nil {
+		return nil, err
+	}
+	return mcp.TextResult(string(payload)), nil
+}
+
+func (c *DockerContextController) handleTest(ctx context.Context, args json.RawMessage) (*mcp.CallToolResult, error) {
+	var p DockerContextTestParams
+	if err := json.Unmarshal(args, &p); err != nil {
+		return nil, fmt.Errorf("invalid docker_context_test params: %w", err)
+	}
+
+	result := c.hosts.TestConnection(ctx, p.Name)
+	payload, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	return mcp.TextResult(string(payload)), nil
+}
+
+func (c *DockerContextController) handleSetActive(ctx context.Context, args json.RawMessage) (*mcp.CallToolResult, error) {
+	var p DockerHostSetActiveParams
+	if err := json.Unmarshal(args, &p); err != nil {
+		return nil, fmt.Errorf("invalid docker_host_set_active params: %w", err)
+	}
+	if err := c.hosts.SwitchHost(p.Name); err != nil {
+		return mcp.ErrorResult(err.Error()), nil
+	}
+	if p.Name == "" {
+		return mcp.TextResult("active docker host reset to the CLI default"), nil
+	}
+	return mcp.TextResult(fmt.Sprintf("active docker host set to %s", p.Name)), nil
+}
+
+func (c *DockerContextController) handleAddHost(ctx context.Context, args json.RawMessage) (*mcp.CallToolResult, error) {
+	var p DockerHostAddParams
+	if err := json.Unmarshal(args, &p); err != nil {
+		return nil, fmt.Errorf("invalid docker_host_add params: %w", err)
+	}
+	host := dockerhost.Host{
+		Name:         p.Name,
+		Endpoint:     p.Endpoint,
+		KeyPath:      p.KeyPath,
+		PasswordEnv:  p.PasswordEnv,
+		PasswordFile: p.PasswordFile,
+	}
+	if err := c.hosts.AddHost(host); err != nil {
+		return mcp.ErrorResult(err.Error()), nil
+	}
+	return mcp.TextResult(fmt.Sprintf("added docker host %s", p.Name)), nil
+}
+
+func (c *DockerContextController) handleRemoveHost(ctx context.Context, args json.RawMessage) (*mcp.CallToolResult, error) {
+	var p DockerHostRemoveParams
+	if err := json.Unmarshal(args, &p); err != nil {
+		return nil, fmt.Errorf("invalid docker_host_remove params: %w", err)
+	}
+	if err := c.hosts.RemoveHost(p.Name); err != nil {
+		return mcp.ErrorResult(err.Error()), nil
+	}
+	return mcp.TextResult(fmt.Sprintf("removed docker host %s", p.Name)), nil
+}
+
+func (c *DockerContextController) handleListHosts(ctx context.Context, args json.RawMessage) (*mcp.CallToolResult, error) {
+	payload, err := json.Marshal(c.hosts.Hosts())
+	if err != nil {
+		return nil, err
+	}
+	return mcp.TextResult(string(payload)), nil
+}
@@ -0,0 +1,40 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/jontolof/docker-compose-mcp/internal/dockerhost"
+)
+
+func TestDockerContextControllerAddListRemoveHost(t *testing.T) {
+	hosts := dockerhost.NewHostManager("")
+	c := NewDockerContextController(hosts)
+	ctx := context.Background()
+
+	addArgs, _ := json.Marshal(DockerHostAddParams{Name: "staging", Endpoint: "ssh://user@staging.example.com"})
+	if _, err := c.handleAddHost(ctx, addArgs); err != nil {
+		t.Fatalf("handleAddHost: %v", err)
+	}
+
+	listResult, err := c.handleListHosts(ctx, nil)
+	if err != nil {
+		t.Fatalf("handleListHosts: %v", err)
+	}
+	var got []dockerhost.Host
+	if err := json.Unmarshal([]byte(listResult.Content[0].Text), &got); err != nil {
+		t.Fatalf("unmarshal host list: %v", err)
+	}
+	if len(got) != 1 || got[0].Name != "staging" {
+		t.Fatalf("hosts = %+v, want one host named staging", got)
+	}
+
+	removeArgs, _ := json.Marshal(DockerHostRemoveParams{Name: "staging"})
+	if _, err := c.handleRemoveHost(ctx, removeArgs); err != nil {
+		t.Fatalf("handleRemoveHost: %v", err)
+	}
+	if len(hosts.Hosts()) != 0 {
+		t.Fatalf("hosts.Hosts() after remove = %+v, want empty", hosts.Hosts())
+	}
+}
@@ -0,0 +1,102 @@
+// Package server implements MCP tools that report on and control the
+// server itself, as opposed to a specific Docker Compose project.
+package server
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/jontolof/docker-compose-mcp/internal/compose"
+	"github.com/jontolof/docker-compose-mcp/internal/config"
+	"github.com/jontolof/docker-compose-mcp/internal/mcp"
+)
+
+// FeatureState reports whether one optional subsystem is enabled and the
+// key configuration values that shape it.
+type FeatureState struct {
+	Enabled bool                   `json:"enabled"`
+	Config  map[string]interface{} `json:"config,omitempty"`
+}
+
+// FeaturesReport is the result of the server_features tool.
+type FeaturesReport struct {
+	Cache     FeatureState `json:"cache"`
+	Metrics   FeatureState `json:"metrics"`
+	Parallel  FeatureState `json:"parallel"`
+	Plugins   FeatureState `json:"plugins"`
+	Profiling FeatureState `json:"profiling"`
+	MultiHost FeatureState `json:"multiHost"`
+}
+
+// FeaturesController exposes the server_features tool.
+type FeaturesController struct {
+	cfg   *config.Config
+	cache *compose.ConfigCache
+}
+
+// NewFeaturesController creates a FeaturesController reporting on cfg.
+// cache is optional; pass nil to omit cache hit/miss stats from the
+// report.
+func NewFeaturesController(cfg *config.Config, cache *compose.ConfigCache) *FeaturesController {
+	return &FeaturesController{cfg: cfg, cache: cache}
+}
+
+// Tools returns the MCP tool definitions and handlers this controller
+// implements.
+func (c *FeaturesController) Tools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Definition: mcp.ToolDefinition{
+				Name:        "server_features",
+				Description: "Report which optional server subsystems (cache, metrics, parallel execution, plugins, profiling, multi-host) are enabled and their key configuration.",
+				InputSchema: json.RawMessage(`{"type": "object", "properties": {}}`),
+			},
+			Handler: c.handleFeatures,
+		},
+	}
+}
+
+func (c *FeaturesController) handleFeatures(ctx context.Context, args json.RawMessage) (*mcp.CallToolResult, error) {
+	report := c.report()
+	payload, err := json.Marshal(report)
+	if err != nil {
+		return nil, err
+	}
+	return mcp.TextResult(string(payload)), nil
+}
+
+func (c *FeaturesController) report() FeaturesReport {
+	cfg := c.cfg
+	cacheConfig := map[string]interface{}{"maxSize": cfg.CacheMaxSize}
+	if c.cache != nil {
+		stats := c.cache.Stats()
+		cacheConfig["entries"] = stats.Entries
+		cacheConfig["hits"] = stats.Hits
+		cacheConfig["misses"] = stats.Misses
+		cacheConfig["hitRatio"] = stats.HitRatio
+	}
+	return FeaturesReport{
+		Cache: FeatureState{
+			Enabled: cfg.CacheEnabled,
+			Config:  cacheConfig,
+		},
+		Metrics: FeatureState{
+			Enabled: cfg.MetricsEnabled,
+			Config:  map[string]interface{}{"targetReduction": cfg.MetricsTargetReduction},
+		},
+		Parallel: FeatureState{
+			Enabled: cfg.ParallelEnabled,
+			Config:  map[string]interface{}{"maxWorkers": cfg.MaxWorkers},
+		},
+		Plugins: FeatureState{
+			Enabled: cfg.PluginsEnabled,
+			Config:  map[string]interface{}{"pluginCount": cfg.PluginCount},
+		},
+		Profiling: FeatureState{
+			Enabled: cfg.ProfilingEnabled,
+		},
+		MultiHost: FeatureState{
+			Enabled: cfg.MultiHostEnabled,
+		},
+	}
+}
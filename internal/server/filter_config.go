@@ -0,0 +1,53 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/jontolof/docker-compose-mcp/internal/filter"
+	"github.com/jontolof/docker-compose-mcp/internal/mcp"
+)
+
+// FilterConfigReport is the result of the filter_config tool.
+type FilterConfigReport struct {
+	Default   filter.Config            `json:"default"`
+	Overrides map[string]filter.Config `json:"overrides,omitempty"`
+}
+
+// FilterController exposes tools that report on the output filtering
+// engine's configuration.
+type FilterController struct {
+	filter *filter.Filter
+}
+
+// NewFilterController creates a FilterController reporting on f.
+func NewFilterController(f *filter.Filter) *FilterController {
+	return &FilterController{filter: f}
+}
+
+// Tools returns the MCP tool definitions and handlers this controller
+// implements.
+func (c *FilterController) Tools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Definition: mcp.ToolDefinition{
+				Name:        "filter_config",
+				Description: "Show the effective output filter configuration: keep/skip patterns, length threshold, and per-command overrides.",
+				InputSchema: json.RawMessage(`{"type": "object", "properties": {}}`),
+			},
+			Handler: c.handleFilterConfig,
+		},
+	}
+}
+
+func (c *FilterController) handleFilterConfig(ctx context.Context, args json.RawMessage) (*mcp.CallToolResult, error) {
+	report := FilterConfigReport{
+		Default:   c.filter.EffectiveConfig(""),
+		Overrides: c.filter.Overrides(),
+	}
+	payload, err := json.Marshal(report)
+	if err != nil {
+		return nil, err
+	}
+	return mcp.TextResult(string(payload)), nil
+}
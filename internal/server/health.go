@@ -0,0 +1,157 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/jontolof/docker-compose-mcp/internal/compose"
+	"github.com/jontolof/docker-compose-mcp/internal/dockerhost"
+	"github.com/jontolof/docker-compose-mcp/internal/filter"
+	"github.com/jontolof/docker-compose-mcp/internal/mcp"
+	"github.com/jontolof/docker-compose-mcp/internal/plugin"
+	"github.com/jontolof/docker-compose-mcp/internal/session"
+)
+
+// filterReductionTarget is the output-reduction ratio the server aims
+// for, per the project's core goal of cutting Docker Compose output by
+// 90%+ without losing operational information.
+const filterReductionTarget = 0.9
+
+// PluginsHealth summarizes the plugin manager's state.
+type PluginsHealth struct {
+	Enabled bool     `json:"enabled"`
+	Loaded  int      `json:"loaded"`
+	Names   []string `json:"names,omitempty"`
+	// Hooks reports slow/failed event hook counts per plugin name, so a
+	// plugin that's technically loaded but unreliable still shows up.
+	Hooks map[string]plugin.HookStats `json:"hooks,omitempty"`
+}
+
+// FilterHealth summarizes whether output filtering is meeting the
+// project's reduction target, averaged across every tool recorded so
+// far.
+type FilterHealth struct {
+	ReductionRatio float64 `json:"reductionRatio"`
+	TargetAchieved bool    `json:"targetAchieved"`
+}
+
+// HealthReport is the result of the server_health tool: a single
+// dashboard-style rollup of everything an operator would otherwise have
+// to check with several separate tool calls.
+type HealthReport struct {
+	OverallStatus string                           `json:"overallStatus"`
+	Plugins       PluginsHealth                    `json:"plugins"`
+	Cache         compose.CacheStats               `json:"cache"`
+	Sessions      session.Metrics                  `json:"sessions"`
+	DockerHost    *dockerhost.ConnectionTestResult `json:"dockerHost,omitempty"`
+	Filter        FilterHealth                     `json:"filter"`
+}
+
+// HealthController exposes the server_health tool, aggregating plugin,
+// cache, session, Docker host, and filter-effectiveness status into a
+// single report.
+type HealthController struct {
+	plugins       *plugin.Manager
+	configCache   *compose.ConfigCache
+	sessions      *session.Manager
+	hosts         *dockerhost.HostManager
+	filterMetrics *filter.FilterMetrics
+}
+
+// NewHealthController creates a HealthController. plugins may be nil
+// when plugin support is disabled.
+func NewHealthController(plugins *plugin.Manager, configCache *compose.ConfigCache, sessions *session.Manager, hosts *dockerhost.HostManager, filterMetrics *filter.FilterMetrics) *HealthController {
+	return &HealthController{
+		plugins:       plugins,
+		configCache:   configCache,
+		sessions:      sessions,
+		hosts:         hosts,
+		filterMetrics: filterMetrics,
+	}
+}
+
+// Tools returns the MCP tool definitions and handlers this controller
+// implements.
+func (c *HealthController) Tools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Definition: mcp.ToolDefinition{
+				Name:        "server_health",
+				Description: "Report overall server health: loaded plugins, config cache effectiveness, active sessions, current Docker host reachability, and whether output filtering is meeting its reduction target.",
+				InputSchema: json.RawMessage(`{"type": "object", "properties": {}}`),
+			},
+			Handler: c.handleHealth,
+		},
+	}
+}
+
+func (c *HealthController) handleHealth(ctx context.Context, args json.RawMessage) (*mcp.CallToolResult, error) {
+	report := HealthReport{
+		OverallStatus: "ok",
+		Plugins:       c.pluginsHealth(),
+		Sessions:      c.sessions.Metrics(),
+		Filter:        c.filterHealth(),
+	}
+	if c.configCache != nil {
+		report.Cache = c.configCache.Stats()
+	}
+	if c.hosts != nil {
+		result := c.hosts.TestConnection(ctx, c.hosts.Active())
+		report.DockerHost = &result
+		if !result.Reachable {
+			report.OverallStatus = "degraded"
+		}
+	}
+	if !report.Filter.TargetAchieved {
+		report.OverallStatus = "degraded"
+	}
+	if c.hooksDegraded(report.Plugins) {
+		report.OverallStatus = "degraded"
+	}
+
+	payload, err := json.Marshal(report)
+	if err != nil {
+		return nil, err
+	}
+	return mcp.TextResult(string(payload)), nil
+}
+
+func (c *HealthController) pluginsHealth() PluginsHealth {
+	if c.plugins == nil {
+		return PluginsHealth{Enabled: false}
+	}
+	infos := c.plugins.List()
+	names := make([]string, 0, len(infos))
+	for _, info := range infos {
+		names = append(names, info.Name)
+	}
+	return PluginsHealth{Enabled: true, Loaded: len(infos), Names: names, Hooks: c.plugins.HookStats()}
+}
+
+// hooksDegraded reports whether any plugin's hooks have run into
+// trouble, for handleHealth to fold into the overall status.
+func (c *HealthController) hooksDegraded(health PluginsHealth) bool {
+	for _, stats := range health.Hooks {
+		if stats.Slow > 0 || stats.Failed > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// filterHealth averages the reduction ratio across every tool recorded
+// so far. A server that hasn't processed any output yet is reported as
+// having achieved its target, since there's nothing to fall short of.
+func (c *HealthController) filterHealth() FilterHealth {
+	snapshot := c.filterMetrics.Snapshot()
+	var inputBytes, outputBytes int64
+	for _, m := range snapshot {
+		inputBytes += m.InputBytes
+		outputBytes += m.OutputBytes
+	}
+	if inputBytes == 0 {
+		return FilterHealth{TargetAchieved: true}
+	}
+	ratio := 1 - float64(outputBytes)/float64(inputBytes)
+	return FilterHealth{ReductionRatio: ratio, TargetAchieved: ratio >= filterReductionTarget}
+}
@@ -0,0 +1,106 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jontolof/docker-compose-mcp/internal/mcp"
+	"github.com/jontolof/docker-compose-mcp/internal/plugin"
+)
+
+// PluginInstallParams are the arguments to the plugin_install tool.
+type PluginInstallParams struct {
+	Source string `json:"source"`
+}
+
+// PluginUninstallParams are the arguments to the plugin_uninstall tool.
+type PluginUninstallParams struct {
+	Name string `json:"name"`
+}
+
+// PluginController exposes tools for installing, removing, and
+// inspecting server plugins. It does not expose tools contributed by
+// plugins themselves: the Plugin interface only reacts to events today,
+// it doesn't register additional MCP tools.
+type PluginController struct {
+	manager *plugin.Manager
+}
+
+// NewPluginController creates a PluginController backed by manager.
+func NewPluginController(manager *plugin.Manager) *PluginController {
+	return &PluginController{manager: manager}
+}
+
+// Tools returns the MCP tool definitions and handlers this controller
+// implements.
+func (c *PluginController) Tools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Definition: mcp.ToolDefinition{
+				Name:        "plugin_install",
+				Description: "Install a plugin from a local .so file path, or (if enabled) a git repository URL.",
+				InputSchema: json.RawMessage(`{
+					"type": "object",
+					"properties": {
+						"source": {"type": "string"}
+					},
+					"required": ["source"]
+				}`),
+			},
+			Handler: c.handleInstall,
+		},
+		{
+			Definition: mcp.ToolDefinition{
+				Name:        "plugin_uninstall",
+				Description: "Unload a plugin and remove its files from disk.",
+				InputSchema: json.RawMessage(`{
+					"type": "object",
+					"properties": {
+						"name": {"type": "string"}
+					},
+					"required": ["name"]
+				}`),
+			},
+			Handler: c.handleUninstall,
+		},
+		{
+			Definition: mcp.ToolDefinition{
+				Name:        "plugin_list",
+				Description: "List currently loaded plugins and their metadata.",
+				InputSchema: json.RawMessage(`{"type": "object", "properties": {}}`),
+			},
+			Handler: c.handleList,
+		},
+	}
+}
+
+func (c *PluginController) handleInstall(ctx context.Context, args json.RawMessage) (*mcp.CallToolResult, error) {
+	var p PluginInstallParams
+	if err := json.Unmarshal(args, &p); err != nil {
+		return nil, fmt.Errorf("invalid plugin_install params: %w", err)
+	}
+	if err := c.manager.Install(p.Source); err != nil {
+		return mcp.ErrorResult(err.Error()), nil
+	}
+	return mcp.TextResult(fmt.Sprintf("installed plugin from %s", p.Source)), nil
+}
+
+func (c *PluginController) handleUninstall(ctx context.Context, args json.RawMessage) (*mcp.CallToolResult, error) {
+	var p PluginUninstallParams
+	if err := json.Unmarshal(args, &p); err != nil {
+		return nil, fmt.Errorf("invalid plugin_uninstall params: %w", err)
+	}
+	if err := c.manager.Uninstall(p.Name); err != nil {
+		return mcp.ErrorResult(err.Error()), nil
+	}
+	return mcp.TextResult(fmt.Sprintf("uninstalled plugin %s", p.Name)), nil
+}
+
+func (c *PluginController) handleList(ctx context.Context, args json.RawMessage) (*mcp.CallToolResult, error) {
+	payload, err := json.Marshal(c.manager.List())
+	if err != nil {
+		return nil, err
+	}
+	return mcp.TextResult(string(payload)), nil
+}
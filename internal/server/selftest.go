@@ -0,0 +1,102 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"os/exec"
+
+	"github.com/jontolof/docker-compose-mcp/internal/mcp"
+)
+
+// SelfTestCheck is the outcome of one startup diagnostic check.
+type SelfTestCheck struct {
+	Name   string `json:"name"`
+	Passed bool   `json:"passed"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// SelfTestReport is the result of the server_selftest tool.
+type SelfTestReport struct {
+	Passed bool            `json:"passed"`
+	Checks []SelfTestCheck `json:"checks"`
+}
+
+// SelfTestFunc runs a single named diagnostic check.
+type SelfTestFunc func(ctx context.Context) SelfTestCheck
+
+// SelfTestController exposes the server_selftest tool, running a
+// configurable set of startup diagnostics.
+type SelfTestController struct {
+	checks []SelfTestFunc
+}
+
+// NewSelfTestController creates a SelfTestController running the given
+// checks, in order. When checks is nil, DefaultSelfTestChecks is used.
+func NewSelfTestController(checks []SelfTestFunc) *SelfTestController {
+	if checks == nil {
+		checks = DefaultSelfTestChecks()
+	}
+	return &SelfTestController{checks: checks}
+}
+
+// DefaultSelfTestChecks returns the checks run when none are configured:
+// Docker CLI availability and Docker Compose plugin availability.
+func DefaultSelfTestChecks() []SelfTestFunc {
+	return []SelfTestFunc{checkDockerAvailable, checkComposeAvailable}
+}
+
+func checkDockerAvailable(ctx context.Context) SelfTestCheck {
+	check := SelfTestCheck{Name: "docker_cli"}
+	if _, err := exec.LookPath("docker"); err != nil {
+		check.Detail = err.Error()
+		return check
+	}
+	check.Passed = true
+	return check
+}
+
+func checkComposeAvailable(ctx context.Context) SelfTestCheck {
+	check := SelfTestCheck{Name: "compose_plugin"}
+	cmd := exec.CommandContext(ctx, "docker", "compose", "version")
+	if err := cmd.Run(); err != nil {
+		check.Detail = err.Error()
+		return check
+	}
+	check.Passed = true
+	return check
+}
+
+// Tools returns the MCP tool definitions and handlers this controller
+// implements.
+func (c *SelfTestController) Tools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Definition: mcp.ToolDefinition{
+				Name:        "server_selftest",
+				Description: "Run startup diagnostics (Docker CLI and Compose plugin availability, and any configured extra checks) and report pass/fail for each.",
+				InputSchema: json.RawMessage(`{"type": "object", "properties": {}}`),
+			},
+			Handler: c.handleSelfTest,
+		},
+	}
+}
+
+func (c *SelfTestController) handleSelfTest(ctx context.Context, args json.RawMessage) (*mcp.CallToolResult, error) {
+	report := SelfTestReport{Passed: true}
+	for _, check := range c.checks {
+		result := check(ctx)
+		report.Checks = append(report.Checks, result)
+		if !result.Passed {
+			report.Passed = false
+		}
+	}
+
+	payload, err := json.Marshal(report)
+	if err != nil {
+		return nil, err
+	}
+	if !report.Passed {
+		return mcp.ErrorResult(string(payload)), nil
+	}
+	return mcp.TextResult(string(payload)), nil
+}
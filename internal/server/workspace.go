@@ -0,0 +1,195 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jontolof/docker-compose-mcp/internal/compose"
+	"github.com/jontolof/docker-compose-mcp/internal/mcp"
+	"github.com/jontolof/docker-compose-mcp/internal/workspace"
+)
+
+// WorkspaceSetDirParams are the arguments to the workspace_set_directory
+// tool.
+type WorkspaceSetDirParams struct {
+	Path string `json:"path"`
+}
+
+// WorkspaceSetComposeFileParams are the arguments to the
+// workspace_set_compose_file tool.
+type WorkspaceSetComposeFileParams struct {
+	Path string `json:"path,omitempty"`
+}
+
+// WorkspaceSetVariablesParams are the arguments to the
+// workspace_set_variables tool.
+type WorkspaceSetVariablesParams struct {
+	Variables map[string]string `json:"variables"`
+}
+
+// WorkspaceDiscoverParams are the arguments to the workspace_discover
+// tool.
+type WorkspaceDiscoverParams struct {
+	Path string `json:"path"`
+	// MaxDepth caps how many directory levels below path are searched.
+	// 0 or omitted means unlimited.
+	MaxDepth int `json:"maxDepth,omitempty"`
+	// Ignore lists gitignore-style glob patterns of directories to
+	// skip, merged with any found in a ".mcpignore" file at path.
+	Ignore []string `json:"ignore,omitempty"`
+}
+
+// WorkspaceController exposes tools for inspecting and changing the
+// directory compose commands run against.
+type WorkspaceController struct {
+	ws                      *workspace.Manager
+	composeValidationStrict bool
+}
+
+// NewWorkspaceController creates a WorkspaceController backed by ws.
+// workspace_set_compose_file always checks the file exists; if
+// composeValidationStrict is true it also runs `docker compose config
+// -q` against it and rejects the change on failure, otherwise it applies
+// the change anyway and reports the failure as a warning.
+func NewWorkspaceController(ws *workspace.Manager, composeValidationStrict bool) *WorkspaceController {
+	return &WorkspaceController{ws: ws, composeValidationStrict: composeValidationStrict}
+}
+
+// Tools returns the MCP tool definitions and handlers this controller
+// implements.
+func (c *WorkspaceController) Tools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Definition: mcp.ToolDefinition{
+				Name:        "workspace_get_directory",
+				Description: "Return the directory compose commands are currently running against.",
+				InputSchema: json.RawMessage(`{"type": "object", "properties": {}}`),
+			},
+			Handler: c.handleGetDir,
+		},
+		{
+			Definition: mcp.ToolDefinition{
+				Name:        "workspace_set_directory",
+				Description: "Change the directory compose commands run against. The change persists across tool calls and server restarts.",
+				InputSchema: json.RawMessage(`{
+					"type": "object",
+					"properties": {
+						"path": {"type": "string"}
+					},
+					"required": ["path"]
+				}`),
+			},
+			Handler: c.handleSetDir,
+		},
+		{
+			Definition: mcp.ToolDefinition{
+				Name:        "workspace_set_compose_file",
+				Description: "Override the compose file the current workspace's commands use instead of compose's own discovery. Pass an empty path to go back to compose's own discovery. The change persists across tool calls and server restarts.",
+				InputSchema: json.RawMessage(`{
+					"type": "object",
+					"properties": {
+						"path": {"type": "string"}
+					}
+				}`),
+			},
+			Handler: c.handleSetComposeFile,
+		},
+		{
+			Definition: mcp.ToolDefinition{
+				Name:        "workspace_set_variables",
+				Description: "Replace the extra environment variables injected into every compose command run against the current workspace. The change persists across tool calls and server restarts.",
+				InputSchema: json.RawMessage(`{
+					"type": "object",
+					"properties": {
+						"variables": {"type": "object", "additionalProperties": {"type": "string"}}
+					},
+					"required": ["variables"]
+				}`),
+			},
+			Handler: c.handleSetVariables,
+		},
+		{
+			Definition: mcp.ToolDefinition{
+				Name:        "workspace_discover",
+				Description: "Find directories under path containing a compose file. Skips node_modules, vendor, .git, dist, anything matching ignore or a .mcpignore file at path, and reports how many directories were skipped. maxDepth caps how many directory levels below path are searched; 0 (the default) means unlimited.",
+				InputSchema: json.RawMessage(`{
+					"type": "object",
+					"properties": {
+						"path": {"type": "string"},
+						"maxDepth": {"type": "integer", "minimum": 0},
+						"ignore": {"type": "array", "items": {"type": "string"}, "description": "Gitignore-style glob patterns of directories to skip."}
+					},
+					"required": ["path"]
+				}`),
+			},
+			Handler: c.handleDiscover,
+		},
+	}
+}
+
+func (c *WorkspaceController) handleGetDir(ctx context.Context, args json.RawMessage) (*mcp.CallToolResult, error) {
+	return mcp.TextResult(c.ws.Dir()), nil
+}
+
+func (c *WorkspaceController) handleSetDir(ctx context.Context, args json.RawMessage) (*mcp.CallToolResult, error) {
+	var p WorkspaceSetDirParams
+	if err := json.Unmarshal(args, &p); err != nil {
+		return nil, fmt.Errorf("invalid workspace_set_directory params: %w", err)
+	}
+	if err := c.ws.SetDir(p.Path); err != nil {
+		return mcp.ErrorResult(err.Error()), nil
+	}
+	return mcp.TextResult(fmt.Sprintf("working directory set to %s", c.ws.Dir())), nil
+}
+
+func (c *WorkspaceController) handleSetComposeFile(ctx context.Context, args json.RawMessage) (*mcp.CallToolResult, error) {
+	var p WorkspaceSetComposeFileParams
+	if len(args) > 0 {
+		if err := json.Unmarshal(args, &p); err != nil {
+			return nil, fmt.Errorf("invalid workspace_set_compose_file params: %w", err)
+		}
+	}
+	if err := c.ws.SetComposeFile(p.Path); err != nil {
+		return mcp.ErrorResult(err.Error()), nil
+	}
+	if p.Path == "" {
+		return mcp.TextResult("compose file override cleared"), nil
+	}
+
+	if err := compose.ValidateComposeFile(ctx, c.ws.Dir(), c.ws.ComposeFile()); err != nil {
+		if c.composeValidationStrict {
+			c.ws.SetComposeFile("")
+			return mcp.ErrorResult(err.Error()), nil
+		}
+		return mcp.TextResult(fmt.Sprintf("compose file set to %s (warning: %s)", c.ws.ComposeFile(), err.Error())), nil
+	}
+	return mcp.TextResult(fmt.Sprintf("compose file set to %s", c.ws.ComposeFile())), nil
+}
+
+func (c *WorkspaceController) handleSetVariables(ctx context.Context, args json.RawMessage) (*mcp.CallToolResult, error) {
+	var p WorkspaceSetVariablesParams
+	if err := json.Unmarshal(args, &p); err != nil {
+		return nil, fmt.Errorf("invalid workspace_set_variables params: %w", err)
+	}
+	if err := c.ws.SetVariables(p.Variables); err != nil {
+		return mcp.ErrorResult(err.Error()), nil
+	}
+	return mcp.TextResult(fmt.Sprintf("%d workspace variable(s) set", len(p.Variables))), nil
+}
+
+func (c *WorkspaceController) handleDiscover(ctx context.Context, args json.RawMessage) (*mcp.CallToolResult, error) {
+	var p WorkspaceDiscoverParams
+	if err := json.Unmarshal(args, &p); err != nil {
+		return nil, fmt.Errorf("invalid workspace_discover params: %w", err)
+	}
+	result, err := workspace.DiscoverWorkspaces(p.Path, p.MaxDepth, p.Ignore)
+	if err != nil {
+		return mcp.ErrorResult(err.Error()), nil
+	}
+	payload, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	return mcp.TextResult(string(payload)), nil
+}
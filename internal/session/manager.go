@@ -0,0 +1,332 @@
+// Package session manages long-running Docker Compose operations — watch,
+// follow-mode logs, and similar commands that don't complete in a single
+// request/response cycle. Each operation runs in the background as a
+// Session; callers poll for newly produced output instead of blocking
+// on the whole command.
+package session
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// killGracePeriod is how long a stopped session's subprocess gets to
+// exit after SIGTERM before Wait forcibly kills it.
+const killGracePeriod = 5 * time.Second
+
+// reapInterval is how often the idle reaper checks sessions against
+// their timeout.
+const reapInterval = 30 * time.Second
+
+// Status is the lifecycle state of a Session.
+type Status string
+
+const (
+	StatusRunning Status = "running"
+	StatusStopped Status = "stopped"
+	StatusExited  Status = "exited"
+	StatusFailed  Status = "failed"
+)
+
+// Session tracks one long-running command and accumulates its output so
+// it can be read incrementally.
+type Session struct {
+	ID        string
+	Tool      string
+	StartedAt time.Time
+
+	mu         sync.Mutex
+	buf        bytes.Buffer
+	status     Status
+	err        error
+	cancel     context.CancelFunc
+	lastActive time.Time
+}
+
+// Manager creates and tracks Sessions.
+type Manager struct {
+	mu               sync.Mutex
+	sessions         map[string]*Session
+	nextID           int64
+	stoppedByTimeout int64
+
+	// sessionTimeout stops sessions that produce no new output and go
+	// unread for this long. maxSessions caps how many sessions may be
+	// active at once. Either may be zero to disable the corresponding
+	// limit.
+	sessionTimeout time.Duration
+	maxSessions    int
+
+	done chan struct{}
+}
+
+// Metrics summarizes session activity across the Manager's lifetime, for
+// spotting sessions that leak (started but never stopped or read).
+type Metrics struct {
+	Created          int           `json:"created"`
+	Active           int           `json:"active"`
+	AverageLifetime  time.Duration `json:"averageLifetime"`
+	StoppedByTimeout int           `json:"stoppedByTimeout"`
+}
+
+// NewManager creates a Manager that reaps sessions idle longer than
+// sessionTimeout and rejects new sessions once maxSessions are active.
+// Either limit may be zero to disable it.
+func NewManager(sessionTimeout time.Duration, maxSessions int) *Manager {
+	m := &Manager{
+		sessions:       make(map[string]*Session),
+		sessionTimeout: sessionTimeout,
+		maxSessions:    maxSessions,
+		done:           make(chan struct{}),
+	}
+	if sessionTimeout > 0 {
+		go m.reapLoop()
+	}
+	return m
+}
+
+// Close stops the idle reaper. It does not stop active sessions.
+func (m *Manager) Close() {
+	select {
+	case <-m.done:
+	default:
+		close(m.done)
+	}
+}
+
+func (m *Manager) reapLoop() {
+	ticker := time.NewTicker(reapInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-m.done:
+			return
+		case <-ticker.C:
+			m.reapIdle()
+		}
+	}
+}
+
+func (m *Manager) reapIdle() {
+	m.mu.Lock()
+	var toStop []*Session
+	for _, s := range m.sessions {
+		if status, _ := s.State(); status != StatusRunning {
+			continue
+		}
+		if time.Since(s.idleSince()) >= m.sessionTimeout {
+			toStop = append(toStop, s)
+		}
+	}
+	m.mu.Unlock()
+
+	for _, s := range toStop {
+		s.Stop()
+		m.mu.Lock()
+		m.stoppedByTimeout++
+		m.mu.Unlock()
+	}
+}
+
+// activeCount reports how many tracked sessions are still running.
+func (m *Manager) activeCount() int {
+	active := 0
+	for _, s := range m.sessions {
+		if status, _ := s.State(); status == StatusRunning {
+			active++
+		}
+	}
+	return active
+}
+
+// Start runs name with args as a long-lived subprocess in dir, streaming
+// its combined output into a new Session. It returns an error without
+// starting the command if maxSessions are already active.
+func (m *Manager) Start(ctx context.Context, tool, dir, name string, args []string) (*Session, error) {
+	m.mu.Lock()
+	if m.maxSessions > 0 && m.activeCount() >= m.maxSessions {
+		m.mu.Unlock()
+		return nil, fmt.Errorf("max sessions (%d) reached", m.maxSessions)
+	}
+	m.mu.Unlock()
+
+	ctx, cancel := context.WithCancel(ctx)
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = dir
+	// On cancellation, ask the process to exit cleanly before Wait
+	// resorts to killing it, so a stopped `compose logs -f` or
+	// `compose watch` gets a chance to flush and exit on its own.
+	cmd.Cancel = func() error {
+		return cmd.Process.Signal(syscall.SIGTERM)
+	}
+	cmd.WaitDelay = killGracePeriod
+
+	now := time.Now()
+	sess := &Session{
+		ID:         m.allocateID(),
+		Tool:       tool,
+		StartedAt:  now,
+		status:     StatusRunning,
+		cancel:     cancel,
+		lastActive: now,
+	}
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("attach stdout: %w", err)
+	}
+	cmd.Stderr = cmd.Stdout
+
+	if err := cmd.Start(); err != nil {
+		cancel()
+		return nil, fmt.Errorf("start %s: %w", name, err)
+	}
+
+	m.mu.Lock()
+	m.sessions[sess.ID] = sess
+	m.mu.Unlock()
+
+	go sess.pump(stdout)
+	go sess.wait(cmd)
+
+	return sess, nil
+}
+
+func (m *Manager) allocateID() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.nextID++
+	return fmt.Sprintf("sess-%d", m.nextID)
+}
+
+// pump continuously copies subprocess output into the session's buffer
+// as it's produced, rather than waiting for the command to exit.
+func (s *Session) pump(r interface {
+	Read(p []byte) (int, error)
+}) {
+	chunk := make([]byte, 4096)
+	for {
+		n, err := r.Read(chunk)
+		if n > 0 {
+			s.mu.Lock()
+			s.buf.Write(chunk[:n])
+			s.lastActive = time.Now()
+			s.mu.Unlock()
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (s *Session) wait(cmd *exec.Cmd) {
+	err := cmd.Wait()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.status == StatusStopped {
+		return
+	}
+	if err != nil {
+		s.status = StatusFailed
+		s.err = err
+		return
+	}
+	s.status = StatusExited
+}
+
+// Stop cancels the session's subprocess, sending it SIGTERM and giving
+// it killGracePeriod to exit before the subprocess is killed outright.
+func (s *Session) Stop() {
+	s.mu.Lock()
+	if s.status == StatusRunning {
+		s.status = StatusStopped
+	}
+	s.mu.Unlock()
+	s.cancel()
+}
+
+// Status returns the session's current lifecycle state and, if it
+// failed, the resulting error.
+func (s *Session) State() (Status, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.status, s.err
+}
+
+// ReadFrom returns the output produced since byte offset cursor, along
+// with the new cursor to pass on the next call.
+func (s *Session) ReadFrom(cursor int) (chunk string, newCursor int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lastActive = time.Now()
+
+	data := s.buf.Bytes()
+	if cursor < 0 || cursor > len(data) {
+		cursor = 0
+	}
+	return string(data[cursor:]), len(data)
+}
+
+// idleSince returns when the session last produced output or was read,
+// for measuring how long it's been idle.
+func (s *Session) idleSince() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastActive
+}
+
+// Get returns the session with the given ID, if it exists.
+func (m *Manager) Get(id string) (*Session, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.sessions[id]
+	return s, ok
+}
+
+// List returns all tracked sessions.
+func (m *Manager) List() []*Session {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make([]*Session, 0, len(m.sessions))
+	for _, s := range m.sessions {
+		out = append(out, s)
+	}
+	return out
+}
+
+// Metrics reports how many sessions have been created, how many are
+// currently running, their average lifetime so far, and how many were
+// stopped by the idle reaper rather than explicitly.
+func (m *Manager) Metrics() Metrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var active int
+	var totalLifetime time.Duration
+	for _, s := range m.sessions {
+		if status, _ := s.State(); status == StatusRunning {
+			active++
+		}
+		totalLifetime += time.Since(s.StartedAt)
+	}
+
+	var avg time.Duration
+	if len(m.sessions) > 0 {
+		avg = totalLifetime / time.Duration(len(m.sessions))
+	}
+
+	return Metrics{
+		Created:          int(m.nextID),
+		Active:           active,
+		AverageLifetime:  avg,
+		StoppedByTimeout: int(m.stoppedByTimeout),
+	}
+}
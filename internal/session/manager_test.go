@@ -0,0 +1,52 @@
+package session
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestSessionStopTerminatesSubprocess(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires a POSIX shell")
+	}
+
+	dir := t.TempDir()
+	marker := filepath.Join(dir, "terminated")
+
+	m := NewManager(0, 0)
+	defer m.Close()
+
+	// Looping over one-second sleeps, rather than a single long sleep,
+	// matters here: POSIX shells are allowed to defer a trapped signal
+	// until the foreground command finishes, so a single `sleep 30`
+	// could leave the trap unrun until the sleep itself completes. A
+	// short sleep gives the shell a check-in point at least once a
+	// second regardless of which shell /bin/sh resolves to.
+	script := `trap 'echo stopped > ` + marker + `; exit 0' TERM; while :; do sleep 1; done`
+	sess, err := m.Start(context.Background(), "watch", dir, "sh", []string{"-c", script})
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	if status, _ := sess.State(); status != StatusRunning {
+		t.Fatalf("status after Start = %v, want %v", status, StatusRunning)
+	}
+
+	sess.Stop()
+
+	// The loop would still be running if Stop didn't actually signal the
+	// process; the trap writing the marker within a few seconds proves
+	// SIGTERM reached it.
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if _, err := os.Stat(marker); err == nil {
+			return
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Fatal("subprocess did not receive SIGTERM within the grace period")
+}
@@ -0,0 +1,67 @@
+// Package shutdown coordinates an orderly server exit. Subsystems that
+// need to finish or cancel outstanding work register a cleanup step;
+// Run invokes them within a bounded timeout so a slow or hung step
+// can't block the process from exiting.
+package shutdown
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// closer is one registered cleanup step.
+type closer struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+// Manager collects cleanup steps and runs them in order on shutdown.
+type Manager struct {
+	mu      sync.Mutex
+	closers []closer
+}
+
+// NewManager creates an empty Manager.
+func NewManager() *Manager {
+	return &Manager{}
+}
+
+// Register adds fn to the set of steps Run invokes on shutdown, in
+// registration order. name identifies the step in the shutdown log.
+func (m *Manager) Register(name string, fn func(ctx context.Context) error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.closers = append(m.closers, closer{name: name, fn: fn})
+}
+
+// Run invokes every registered step, bounding the whole sequence to
+// timeout (0 means no bound), and logs each step's outcome to log. It
+// returns the first error encountered, after still running the
+// remaining steps.
+func (m *Manager) Run(timeout time.Duration, log io.Writer) error {
+	m.mu.Lock()
+	closers := append([]closer(nil), m.closers...)
+	m.mu.Unlock()
+
+	ctx := context.Background()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	var firstErr error
+	for _, c := range closers {
+		if err := c.fn(ctx); err != nil {
+			fmt.Fprintf(log, "shutdown: %s: %v\n", c.name, err)
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+	}
+	return firstErr
+}
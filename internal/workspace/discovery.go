@@ -0,0 +1,155 @@
+package workspace
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// skippedDiscoveryDirs are directory names DiscoverWorkspaces never
+// descends into: dependency trees and VCS metadata that are large, slow
+// to walk, and never contain a compose file of their own.
+var skippedDiscoveryDirs = map[string]bool{
+	"node_modules": true,
+	"vendor":       true,
+	".git":         true,
+	"dist":         true,
+}
+
+// discoveryComposeFiles lists the compose file names that mark a
+// directory as a workspace, same set compose.findComposeFile tries.
+var discoveryComposeFiles = []string{"docker-compose.yml", "docker-compose.yaml", "compose.yml", "compose.yaml"}
+
+// ignoreFileName is the per-tree file DiscoverWorkspaces reads extra
+// ignore patterns from, alongside whatever the caller passes directly.
+const ignoreFileName = ".mcpignore"
+
+// DiscoverResult is the result of DiscoverWorkspaces: the workspaces it
+// found and how many directories its ignore patterns skipped, so a
+// caller can confirm their excludes actually took effect.
+type DiscoverResult struct {
+	Workspaces  []string `json:"workspaces"`
+	SkippedDirs int      `json:"skippedDirs"`
+}
+
+// DiscoverWorkspaces walks root looking for directories containing a
+// compose file, descending at most maxDepth levels below root; maxDepth
+// <= 0 means unlimited. Known heavy directories (node_modules, vendor,
+// .git, dist) are always skipped. ignore adds gitignore-style glob
+// patterns of its own, merged with any found in a ".mcpignore" file at
+// root: a pattern containing "/" matches the directory's path relative
+// to root, otherwise it matches just the directory's base name, the
+// same way a plain gitignore entry does for a single path segment.
+func DiscoverWorkspaces(root string, maxDepth int, ignore []string) (*DiscoverResult, error) {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return nil, fmt.Errorf("resolve path: %w", err)
+	}
+
+	patterns := append([]string(nil), ignore...)
+	patterns = append(patterns, readIgnoreFile(filepath.Join(root, ignoreFileName))...)
+
+	result := &DiscoverResult{}
+	err = filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if p == root {
+			return nil
+		}
+		if skippedDiscoveryDirs[d.Name()] || matchesIgnore(patterns, relSlash(root, p), d.Name()) {
+			result.SkippedDirs++
+			return filepath.SkipDir
+		}
+		if maxDepth > 0 && depthBelow(root, p) > maxDepth {
+			return filepath.SkipDir
+		}
+		if hasComposeFile(p) {
+			result.Workspaces = append(result.Workspaces, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("walk %s: %w", root, err)
+	}
+
+	sort.Strings(result.Workspaces)
+	return result, nil
+}
+
+// readIgnoreFile parses a gitignore-style pattern file: one pattern per
+// line, blank lines and "#" comments ignored. A missing file yields no
+// patterns rather than an error, since a .mcpignore is always optional.
+func readIgnoreFile(path string) []string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var patterns []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		patterns = append(patterns, line)
+	}
+	return patterns
+}
+
+// matchesIgnore reports whether rel (path relative to the discovery
+// root, using "/" separators) or its base name matches any pattern.
+func matchesIgnore(patterns []string, rel, base string) bool {
+	for _, p := range patterns {
+		p = strings.TrimSuffix(strings.TrimSpace(p), "/")
+		if p == "" {
+			continue
+		}
+		if strings.Contains(p, "/") {
+			if ok, _ := path.Match(p, rel); ok {
+				return true
+			}
+			continue
+		}
+		if ok, _ := path.Match(p, base); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// relSlash returns path relative to root with forward slashes, so
+// ignore patterns behave the same regardless of OS.
+func relSlash(root, path string) string {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return filepath.ToSlash(path)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// depthBelow reports how many directory levels path is below root.
+func depthBelow(root, path string) int {
+	rel, err := filepath.Rel(root, path)
+	if err != nil || rel == "." {
+		return 0
+	}
+	return strings.Count(rel, string(filepath.Separator)) + 1
+}
+
+// hasComposeFile reports whether dir contains one of the standard
+// compose file names.
+func hasComposeFile(dir string) bool {
+	for _, name := range discoveryComposeFiles {
+		if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,199 @@
+// Package workspace tracks the working directory that compose commands
+// run against. A single MCP server process serves many sequential tool
+// calls, so the active directory is shared, mutable state that must be
+// read and written safely and survive process restarts.
+package workspace
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/jontolof/docker-compose-mcp/internal/plugin"
+)
+
+// Manager holds the current working directory, guarding it against
+// concurrent tool calls and persisting changes to disk.
+type Manager struct {
+	mu          sync.RWMutex
+	dir         string
+	composeFile string
+	variables   map[string]string
+	statePath   string
+	events      *plugin.Manager
+}
+
+// state is the on-disk persisted form of the manager.
+type state struct {
+	Dir         string            `json:"dir"`
+	ComposeFile string            `json:"composeFile,omitempty"`
+	Variables   map[string]string `json:"variables,omitempty"`
+}
+
+// NewManager creates a Manager whose initial directory, compose file
+// override, and variables are the most recently persisted ones at
+// statePath, falling back to defaultDir if none exists or it can't be
+// read.
+func NewManager(statePath, defaultDir string) *Manager {
+	m := &Manager{dir: defaultDir, statePath: statePath}
+	if data, err := os.ReadFile(statePath); err == nil {
+		var s state
+		if json.Unmarshal(data, &s) == nil {
+			if s.Dir != "" {
+				if info, statErr := os.Stat(s.Dir); statErr == nil && info.IsDir() {
+					m.dir = s.Dir
+				}
+			}
+			m.composeFile = s.ComposeFile
+			m.variables = s.Variables
+		}
+	}
+	return m
+}
+
+// Dir returns the current working directory.
+func (m *Manager) Dir() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.dir
+}
+
+// SetEventManager wires a plugin manager into the Manager so switching
+// the working directory fires EventWorkspaceChange. Pass nil (the
+// default, and what a Manager has until this is called) to switch
+// directories without firing events.
+func (m *Manager) SetEventManager(events *plugin.Manager) {
+	m.mu.Lock()
+	m.events = events
+	m.mu.Unlock()
+}
+
+// SetDir changes the current working directory to dir, persisting the
+// change to disk. dir must already exist. Fires EventWorkspaceChange
+// with the new workspace's base name and full path if an event manager
+// is set.
+func (m *Manager) SetDir(dir string) error {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return fmt.Errorf("resolve path: %w", err)
+	}
+	info, err := os.Stat(abs)
+	if err != nil {
+		return fmt.Errorf("stat %s: %w", abs, err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", abs)
+	}
+
+	m.mu.Lock()
+	prev := m.dir
+	m.dir = abs
+	err = m.persist()
+	if err != nil {
+		m.dir = prev
+	}
+	events := m.events
+	m.mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+	if events != nil {
+		events.FireEvent(plugin.Event{
+			Type: plugin.EventWorkspaceChange,
+			Data: map[string]interface{}{"name": filepath.Base(abs), "path": abs},
+		})
+	}
+	return nil
+}
+
+// ComposeFile returns the compose file that commands in the current
+// workspace should use instead of compose's own discovery, or "" if the
+// workspace doesn't override it.
+func (m *Manager) ComposeFile() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.composeFile
+}
+
+// SetComposeFile overrides the compose file used for commands run in the
+// current workspace, persisting the change to disk. Pass "" to go back
+// to compose's own discovery. A non-empty path must already exist.
+func (m *Manager) SetComposeFile(path string) error {
+	if path != "" {
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return fmt.Errorf("resolve path: %w", err)
+		}
+		if _, err := os.Stat(abs); err != nil {
+			return fmt.Errorf("stat %s: %w", abs, err)
+		}
+		path = abs
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	prev := m.composeFile
+	m.composeFile = path
+	if err := m.persist(); err != nil {
+		m.composeFile = prev
+		return err
+	}
+	return nil
+}
+
+// Variables returns a copy of the current workspace's extra environment
+// variables, injected into every compose command run against it.
+func (m *Manager) Variables() map[string]string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	out := make(map[string]string, len(m.variables))
+	for k, v := range m.variables {
+		out[k] = v
+	}
+	return out
+}
+
+// SetVariables replaces the current workspace's extra environment
+// variables, persisting the change to disk.
+func (m *Manager) SetVariables(vars map[string]string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	prev := m.variables
+	m.variables = vars
+	if err := m.persist(); err != nil {
+		m.variables = prev
+		return err
+	}
+	return nil
+}
+
+// persist writes the manager's current state to statePath atomically,
+// via a temp file + rename, so a crash mid-write can't leave a corrupt
+// state file behind. Callers must hold m.mu.
+func (m *Manager) persist() error {
+	if m.statePath == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(m.statePath), 0o755); err != nil {
+		return fmt.Errorf("create state dir: %w", err)
+	}
+
+	data, err := json.Marshal(state{Dir: m.dir, ComposeFile: m.composeFile, Variables: m.variables})
+	if err != nil {
+		return fmt.Errorf("marshal state: %w", err)
+	}
+
+	tmp := m.statePath + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("write temp state: %w", err)
+	}
+	if err := os.Rename(tmp, m.statePath); err != nil {
+		return fmt.Errorf("persist state: %w", err)
+	}
+	return nil
+}
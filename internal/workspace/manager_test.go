@@ -0,0 +1,39 @@
+package workspace
+
+import (
+	"testing"
+
+	"github.com/jontolof/docker-compose-mcp/internal/plugin"
+)
+
+func TestSetDirWithoutEventManagerIsSafe(t *testing.T) {
+	m := NewManager("", t.TempDir())
+	dir := t.TempDir()
+
+	if err := m.SetDir(dir); err != nil {
+		t.Fatalf("SetDir: %v", err)
+	}
+	if m.Dir() != dir {
+		t.Fatalf("Dir() = %q, want %q", m.Dir(), dir)
+	}
+}
+
+func TestSetDirWithEventManagerFiresWithoutPanicking(t *testing.T) {
+	m := NewManager("", t.TempDir())
+	m.SetEventManager(plugin.NewManager(nil))
+	dir := t.TempDir()
+
+	if err := m.SetDir(dir); err != nil {
+		t.Fatalf("SetDir: %v", err)
+	}
+	if m.Dir() != dir {
+		t.Fatalf("Dir() = %q, want %q", m.Dir(), dir)
+	}
+}
+
+func TestSetDirRejectsNonexistentDirectory(t *testing.T) {
+	m := NewManager("", t.TempDir())
+	if err := m.SetDir("/does/not/exist/anywhere"); err == nil {
+		t.Fatal("SetDir should fail for a directory that does not exist")
+	}
+}